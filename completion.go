@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cpuguy83/go-md2man/v2/md2man"
+	"github.com/urfave/cli/v2"
+)
+
+// completionFlag is appended by completion scripts to command line to get suggestions (see cli.App.EnableBashCompletion).
+const completionFlag = "--generate-bash-completion"
+
+const bashCompletion = `# bash completion for golangver, load it with: source <(golangver completion bash)
+_golangver_complete() {
+  local cur opts
+  COMPREPLY=()
+  cur="${COMP_WORDS[COMP_CWORD]}"
+  if [[ "$cur" == "-"* ]]; then
+    opts=$( "${COMP_WORDS[@]:0:$COMP_CWORD}" "${cur}" ` + completionFlag + ` 2>/dev/null )
+  else
+    opts=$( "${COMP_WORDS[@]:0:$COMP_CWORD}" ` + completionFlag + ` 2>/dev/null )
+  fi
+  COMPREPLY=( $(compgen -W "${opts}" -- "${cur}") )
+  return 0
+}
+
+complete -o bashdefault -o default -F _golangver_complete golangver
+`
+
+const zshCompletion = `#compdef golangver
+# zsh completion for golangver, load it with: source <(golangver completion zsh)
+_golangver() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} ${cur} ` + completionFlag + ` 2>/dev/null)}")
+  else
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} ` + completionFlag + ` 2>/dev/null)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+compdef _golangver golangver
+`
+
+// completionScript returns completion script of golangver for shell.
+func (a *app) completionScript(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return bashCompletion, nil
+	case "zsh":
+		return zshCompletion, nil
+	case "fish":
+		script, err := a.app.ToFishCompletion()
+		if err != nil {
+			return "", err
+		}
+		// static completion of fish can't suggest versions, commands with them are completed by golangver itself
+		var b strings.Builder
+		b.WriteString(script)
+		for _, c := range a.app.Commands {
+			if c.BashComplete == nil {
+				continue
+			}
+			fmt.Fprintf(&b, "complete -c golangver -n '__fish_seen_subcommand_from %s' -f -a '(golangver %s %s 2>/dev/null)'\n",
+				strings.Join(c.Names(), " "), c.Name, completionFlag)
+		}
+		return b.String(), nil
+	}
+	return "", fmt.Errorf("unsupported shell %q (bash, zsh or fish is expected)", shell)
+}
+
+// manPage returns roff man page of golangver.
+// Unlike cli.App.ToMan placeholders like <version> are escaped, so they aren't dropped as HTML tags.
+func (a *app) manPage() (string, error) {
+	doc, err := a.app.ToMarkdown()
+	if err != nil {
+		return "", err
+	}
+	// golangver is user command (section 1), template of cli uses section 8
+	doc = strings.Replace(doc, "% "+a.app.Name+" 8\n", "% "+a.app.Name+" 1\n", 1)
+	doc = strings.NewReplacer("<", `\<`, ">", `\>`).Replace(doc)
+	return string(md2man.Render([]byte(doc))), nil
+}
+
+// completeVersions returns completion of command arguments by installed versions and,
+// if remote is set, by versions from cached releases index. Flags are completed as usual.
+func (a *app) completeVersions(remote bool) cli.BashCompleteFunc {
+	return func(cliCtx *cli.Context) {
+		if len(os.Args) > 2 && strings.HasPrefix(os.Args[len(os.Args)-2], "-") {
+			cli.DefaultCompleteWithFlags(cliCtx.Command)(cliCtx)
+			return
+		}
+		versions, err := a.m.CompleteVersions(remote)
+		if err != nil {
+			a.m.Logger.Debug("versions completion is failed", "err", err)
+		}
+		for _, v := range versions {
+			fmt.Fprintln(cliCtx.App.Writer, v)
+		}
+	}
+}
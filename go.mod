@@ -1,15 +1,15 @@
 module github.com/nordicdyno/golangver
 
-go 1.17
+go 1.21
 
 require (
 	github.com/coreos/go-semver v0.3.0
+	github.com/cpuguy83/go-md2man/v2 v2.0.0-20190314233015-f79a8a8ca69d
 	github.com/urfave/cli/v2 v2.3.0
 	golang.org/x/mod v0.5.1
 )
 
 require (
-	github.com/cpuguy83/go-md2man/v2 v2.0.0-20190314233015-f79a8a8ca69d // indirect
 	github.com/russross/blackfriday/v2 v2.0.1 // indirect
 	github.com/shurcooL/sanitized_anchor_name v1.0.0 // indirect
 	golang.org/x/xerrors v0.0.0-20191011141410-1b5146add898 // indirect
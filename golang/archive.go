@@ -0,0 +1,167 @@
+package golang
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// unpackArchive unpacks Go release archive (.tar.gz or .zip) to dest.
+// Top level "go/" directory of archive is stripped.
+// Format is detected by content, because cached archives don't have extension.
+func unpackArchive(archive string, dest string) error {
+	isZip, err := isZipArchive(archive)
+	if err != nil {
+		return err
+	}
+	if isZip {
+		return unpackZip(archive, dest)
+	}
+	return unpackTarGz(archive, dest)
+}
+
+// isZipArchive reports whether archive starts with zip local file header signature.
+func isZipArchive(archive string) (bool, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return false, nil
+		}
+		return false, err
+	}
+	return string(magic) == "PK\x03\x04", nil
+}
+
+// archiveTarget returns path of archive entry in dest directory.
+func archiveTarget(dest string, name string) (string, bool, error) {
+	name = strings.TrimPrefix(filepath.ToSlash(name), "./")
+	rel := strings.TrimPrefix(name, "go/")
+	if rel == name && name != "go" {
+		return "", false, fmt.Errorf("unexpected archive entry %s (must be in go/ directory)", name)
+	}
+	if rel == "" || rel == "go" {
+		return dest, true, nil
+	}
+	target := filepath.Join(dest, filepath.FromSlash(rel))
+	if !strings.HasPrefix(target, filepath.Clean(dest)+string(filepath.Separator)) {
+		return "", false, fmt.Errorf("archive entry %s is outside of destination", name)
+	}
+	return target, false, nil
+}
+
+func unpackTarGz(archive string, dest string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", archive, err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", archive, err)
+		}
+
+		target, isRoot, err := archiveTarget(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if isRoot {
+				return fmt.Errorf("unexpected archive entry %s", hdr.Name)
+			}
+			if err := writeArchiveFile(target, tr, mode.Perm(), hdr.ModTime); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func unpackZip(archive string, dest string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		target, isRoot, err := archiveTarget(dest, zf.Name)
+		if err != nil {
+			return err
+		}
+		if zf.FileInfo().IsDir() || isRoot {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		perm := zf.Mode().Perm()
+		if perm == 0 {
+			perm = 0644
+		}
+		err = writeArchiveFile(target, rc, perm, zf.Modified)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeArchiveFile(target string, r io.Reader, perm os.FileMode, modTime time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if modTime.IsZero() {
+		return nil
+	}
+	return os.Chtimes(target, modTime, modTime)
+}
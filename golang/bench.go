@@ -0,0 +1,327 @@
+package golang
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// benchAlpha is significance level of benchmark metric change.
+const benchAlpha = 0.05
+
+// BenchOpts controls Bench behaviour.
+type BenchOpts struct {
+	// Versions are version specs resolved against installed versions (see MatrixOpts).
+	Versions []string
+	// Count is number of runs of every benchmark (go test -count).
+	Count int
+	// Bench is regexp of benchmarks to run (go test -bench), all benchmarks by default.
+	Bench string
+	// Benchmem enables memory allocation statistics (go test -benchmem).
+	Benchmem bool
+}
+
+// BenchResult is result of Bench.
+type BenchResult struct {
+	Versions []string
+	// Runs are `go test -bench` runs per version, raw output is in Output.
+	Runs []MatrixRun
+	// Benchmarks are metrics of benchmarks in order of appearance.
+	Benchmarks []BenchComparison
+}
+
+// BenchComparison compares metric of benchmark between versions.
+type BenchComparison struct {
+	Package string
+	Name    string
+	// Unit is metric unit (ns/op, B/op, allocs/op or custom one).
+	Unit string
+	// Stats are statistics of metric per version (in order of versions), nil if benchmark isn't run with version.
+	Stats []*BenchStats
+	// Deltas are changes relative to the first version, Deltas[0] is nil as well as deltas of missing stats.
+	Deltas []*BenchDelta
+}
+
+// BenchStats are statistics of benchmark metric samples.
+type BenchStats struct {
+	// Values are samples without outliers.
+	Values []float64
+	Mean   float64
+	// Diff is the max deviation of values from mean relative to mean.
+	Diff float64
+}
+
+// BenchDelta is change of benchmark metric.
+type BenchDelta struct {
+	// Change is relative change of mean.
+	Change float64
+	// P is p-value of Mann-Whitney U-test.
+	P float64
+	// Significant is set if P is less than 0.05.
+	Significant bool
+}
+
+// Bench runs `go test -bench` once per Go version with environment of the version (see Exec)
+// and compares benchmark metrics between versions like benchstat does.
+// Versions are run one by one because concurrent runs affect results.
+// args are packages and other flags of `go test`.
+func (m *Manager) Bench(opts BenchOpts, args []string) (*BenchResult, error) {
+	versions, err := m.ResolveInstalledVersions(opts.Versions)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("versions are not provided")
+	}
+	count, bench := opts.Count, opts.Bench
+	if count < 1 {
+		count = 1
+	}
+	if bench == "" {
+		bench = "."
+	}
+	goArgs := []string{"go", "test", "-run", "^$", "-bench", bench, "-count", strconv.Itoa(count)}
+	if opts.Benchmem {
+		goArgs = append(goArgs, "-benchmem")
+	}
+	goArgs = append(goArgs, args...)
+
+	res := &BenchResult{Versions: versions}
+	samples := newBenchSamples(len(versions))
+	var failed int
+	for i, version := range versions {
+		fmt.Fprintf(m.Stdout, "Benchmark with Go %s...\n", version)
+		out := &prefixWriter{mu: &sync.Mutex{}, w: m.Stdout, prefix: "[" + version + "] "}
+		run := m.runVersion(version, goArgs, out)
+		out.Flush()
+		if !run.Passed() {
+			failed++
+		}
+		res.Runs = append(res.Runs, run)
+		samples.parse(i, run.Output)
+	}
+	res.Benchmarks = samples.compare()
+	if failed > 0 {
+		return res, fmt.Errorf("%d of %d runs failed", failed, len(versions))
+	}
+	return res, nil
+}
+
+type benchKey struct {
+	pkg, name, unit string
+}
+
+// benchSamples are metric values of benchmarks per version.
+type benchSamples struct {
+	keys   []benchKey
+	values map[benchKey][][]float64
+	n      int
+}
+
+func newBenchSamples(n int) *benchSamples {
+	return &benchSamples{values: map[benchKey][][]float64{}, n: n}
+}
+
+// parse collects metrics of version from output of `go test -bench`:
+//
+//	pkg: example.com/foo
+//	BenchmarkFoo-8   	 1000000	      1052 ns/op	      64 B/op	       1 allocs/op
+func (s *benchSamples) parse(version int, output string) {
+	var pkg string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "pkg: ") {
+			pkg = strings.TrimSpace(strings.TrimPrefix(line, "pkg: "))
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		name := strings.TrimPrefix(fields[0], "Benchmark")
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				break
+			}
+			key := benchKey{pkg: pkg, name: name, unit: fields[i+1]}
+			values, ok := s.values[key]
+			if !ok {
+				values = make([][]float64, s.n)
+				s.values[key] = values
+				s.keys = append(s.keys, key)
+			}
+			values[version] = append(values[version], value)
+		}
+	}
+}
+
+func (s *benchSamples) compare() []BenchComparison {
+	var comparisons []BenchComparison
+	for _, key := range s.keys {
+		c := BenchComparison{Package: key.pkg, Name: key.name, Unit: key.unit}
+		for _, values := range s.values[key] {
+			c.Stats = append(c.Stats, newBenchStats(values))
+		}
+		c.Deltas = make([]*BenchDelta, len(c.Stats))
+		base := c.Stats[0]
+		for i := 1; i < len(c.Stats); i++ {
+			if base == nil || c.Stats[i] == nil {
+				continue
+			}
+			c.Deltas[i] = newBenchDelta(base, c.Stats[i])
+		}
+		comparisons = append(comparisons, c)
+	}
+	return comparisons
+}
+
+// newBenchStats returns statistics of samples with outliers removed by interquartile range rule.
+func newBenchStats(samples []float64) *BenchStats {
+	if len(samples) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+	lo, hi := q1-1.5*(q3-q1), q3+1.5*(q3-q1)
+
+	s := &BenchStats{}
+	for _, v := range sorted {
+		if v >= lo && v <= hi {
+			s.Values = append(s.Values, v)
+		}
+	}
+	var sum float64
+	for _, v := range s.Values {
+		sum += v
+	}
+	s.Mean = sum / float64(len(s.Values))
+	if s.Mean != 0 {
+		min, max := s.Values[0], s.Values[len(s.Values)-1]
+		s.Diff = math.Max(max-s.Mean, s.Mean-min) / s.Mean
+	}
+	return s
+}
+
+// quantile returns q-quantile of sorted values with linear interpolation.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+func newBenchDelta(old, new *BenchStats) *BenchDelta {
+	d := &BenchDelta{P: mannWhitneyUTest(old.Values, new.Values)}
+	if old.Mean != 0 {
+		d.Change = (new.Mean - old.Mean) / old.Mean
+	}
+	d.Significant = d.P < benchAlpha
+	return d
+}
+
+// mannWhitneyUTest returns two-sided p-value of Mann-Whitney U-test of samples x and y.
+// Exact distribution of U is used for small samples without ties, normal approximation otherwise.
+func mannWhitneyUTest(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	type sample struct {
+		value float64
+		first bool
+	}
+	all := make([]sample, 0, n1+n2)
+	for _, v := range x {
+		all = append(all, sample{value: v, first: true})
+	}
+	for _, v := range y {
+		all = append(all, sample{value: v})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	// ranks of tied values are averaged
+	var (
+		rankSum float64
+		ties    float64
+		hasTies bool
+	)
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].first {
+				rankSum += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties += t*t*t - t
+			hasTies = true
+		}
+		i = j
+	}
+
+	u1 := rankSum - float64(n1*(n1+1))/2
+	u := math.Min(u1, float64(n1*n2)-u1)
+	if !hasTies && n1*n2 <= 400 {
+		return math.Min(1, 2*mannWhitneyUCDF(n1, n2, int(u)))
+	}
+
+	n := float64(n1 + n2)
+	mu := float64(n1*n2) / 2
+	sigma := math.Sqrt(float64(n1*n2) / 12 * ((n + 1) - ties/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	z := (mu - u - 0.5) / sigma
+	if z < 0 {
+		return 1
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// mannWhitneyUCDF returns P(U <= u) for samples of sizes n1 and n2 without ties.
+func mannWhitneyUCDF(n1, n2, u int) float64 {
+	// counts[i][j][k] is number of arrangements of i and j samples with U = k:
+	// the largest value is either from the first sample (adding j to U) or from the second one.
+	maxU := n1 * n2
+	counts := make([][][]float64, n1+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n2+1)
+		for j := range counts[i] {
+			counts[i][j] = make([]float64, maxU+1)
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+				continue
+			}
+			for k := 0; k <= i*j; k++ {
+				if k >= j {
+					counts[i][j][k] += counts[i-1][j][k-j]
+				}
+				counts[i][j][k] += counts[i][j-1][k]
+			}
+		}
+	}
+	var below, total float64
+	for k, c := range counts[n1][n2] {
+		if k <= u {
+			below += c
+		}
+		total += c
+	}
+	return below / total
+}
@@ -0,0 +1,100 @@
+package golang
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMannWhitneyUTest(t *testing.T) {
+	// p-values are the ones of R wilcox.test(x, y) (exact = FALSE, correct = TRUE for ties)
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+	}{
+		{"separated", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 0.007937},
+		{"separated reversed", []float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 0.007937},
+		{"interleaved", []float64{1, 3, 5, 7, 9}, []float64{2, 4, 6, 8, 10}, 0.6905},
+		{"small", []float64{1, 2, 3}, []float64{4, 5, 6}, 0.1},
+		{"benchmark", []float64{10.1, 10.3, 10.2, 10.6, 10.4, 10.5}, []float64{10.9, 11.2, 10.7, 10.8, 11.4, 11.0}, 0.002165},
+		{"ties", []float64{1, 2, 2, 3, 4}, []float64{3, 4, 5, 5, 6}, 0.03445},
+		{"all tied", []float64{5, 5, 5}, []float64{5, 5, 5}, 1},
+		{"empty", nil, []float64{1, 2}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mannWhitneyUTest(tt.x, tt.y); math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("p = %.6f, want %.6f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBenchStats(t *testing.T) {
+	s := newBenchStats([]float64{12, 10, 100, 13, 11})
+	if !reflect.DeepEqual(s.Values, []float64{10, 11, 12, 13}) {
+		t.Errorf("values %v, outlier 100 is expected to be removed", s.Values)
+	}
+	if s.Mean != 11.5 || math.Abs(s.Diff-1.5/11.5) > 1e-9 {
+		t.Errorf("mean %v, diff %v, want 11.5, %v", s.Mean, s.Diff, 1.5/11.5)
+	}
+	if newBenchStats(nil) != nil {
+		t.Error("statistics of no samples")
+	}
+}
+
+func TestBenchSamplesParse(t *testing.T) {
+	s := newBenchSamples(2)
+	s.parse(0, `goos: linux
+goarch: amd64
+pkg: example.com/foo
+cpu: Intel(R) Core(TM) i7
+BenchmarkFoo-8   	 1000000	      1052 ns/op	      64 B/op	       1 allocs/op
+BenchmarkFoo-8   	 1000000	      1060 ns/op	      64 B/op	       1 allocs/op
+BenchmarkCopy-8  	    5000	    250000 ns/op	  419.43 MB/s
+PASS
+ok  	example.com/foo	3.210s
+pkg: example.com/bar
+BenchmarkFoo-8   	  500000	      2000 ns/op
+Benchmark results are above
+PASS
+`)
+	s.parse(1, `pkg: example.com/foo
+BenchmarkFoo-8   	 2000000	       900 ns/op	      32 B/op	       1 allocs/op
+`)
+
+	want := []benchKey{
+		{"example.com/foo", "Foo-8", "ns/op"},
+		{"example.com/foo", "Foo-8", "B/op"},
+		{"example.com/foo", "Foo-8", "allocs/op"},
+		{"example.com/foo", "Copy-8", "ns/op"},
+		{"example.com/foo", "Copy-8", "MB/s"},
+		{"example.com/bar", "Foo-8", "ns/op"},
+	}
+	if !reflect.DeepEqual(s.keys, want) {
+		t.Fatalf("keys %v, want %v", s.keys, want)
+	}
+	for key, want := range map[benchKey][][]float64{
+		{"example.com/foo", "Foo-8", "ns/op"}:     {{1052, 1060}, {900}},
+		{"example.com/foo", "Foo-8", "B/op"}:      {{64, 64}, {32}},
+		{"example.com/foo", "Foo-8", "allocs/op"}: {{1, 1}, {1}},
+		{"example.com/foo", "Copy-8", "MB/s"}:     {{419.43}, nil},
+		{"example.com/bar", "Foo-8", "ns/op"}:     {{2000}, nil},
+	} {
+		if got := s.values[key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: values %v, want %v", key, got, want)
+		}
+	}
+
+	comparisons := s.compare()
+	if len(comparisons) != len(want) {
+		t.Fatalf("%d comparisons, want %d", len(comparisons), len(want))
+	}
+	if d := comparisons[1].Deltas[1]; d == nil || d.Change != -0.5 {
+		t.Errorf("B/op delta %+v, change -0.5 is expected", d)
+	}
+	if c := comparisons[5]; c.Stats[1] != nil || c.Deltas[1] != nil {
+		t.Errorf("benchmark isn't run with second version: %+v", c)
+	}
+}
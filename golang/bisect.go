@@ -0,0 +1,205 @@
+package golang
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+)
+
+// Verdicts of bisect step.
+const (
+	BisectGood = "good"
+	BisectBad  = "bad"
+	BisectSkip = "skip"
+)
+
+// bisectSkipCode is exit code of command which can't test version (like in `git bisect run`).
+const bisectSkipCode = 125
+
+// BisectOpts controls Bisect behaviour.
+type BisectOpts struct {
+	// Good is version spec of release command succeeds with.
+	Good string
+	// Bad is version spec of newer release command fails with.
+	Bad string
+	// Install is used for versions which are not installed (team policy is ignored).
+	Install InstallOpts
+}
+
+// BisectStep is result of command run with Go version.
+type BisectStep struct {
+	MatrixRun
+	// Verdict is BisectGood, BisectBad or BisectSkip.
+	Verdict string
+	// Installed is set if version is installed by bisect.
+	Installed bool
+}
+
+// BisectResult is result of Bisect.
+type BisectResult struct {
+	Good string
+	Bad  string
+	// FirstBad is the first release command fails with.
+	FirstBad string
+	// LastGood is the newest release before FirstBad command succeeds with.
+	LastGood string
+	// Skipped are releases between LastGood and FirstBad which couldn't be tested:
+	// any of them can be the first bad release.
+	Skipped []string
+	// Steps are command runs in order of execution.
+	Steps []BisectStep
+}
+
+// Bisect finds the first stable release between good and bad releases command fails with
+// by binary search over remote releases index.
+// Command is run with environment of version (see Exec), missing versions are installed.
+// Exit code 0 means good version, 125 means version can't be tested (skip),
+// other exit codes below 128 mean bad version, the rest abort bisect.
+// Releases between good and bad which can't be installed are skipped too.
+func (m *Manager) Bisect(opts BisectOpts, args []string) (*BisectResult, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("command is not provided")
+	}
+	if opts.Good == "" || opts.Bad == "" {
+		return nil, fmt.Errorf("good and bad versions must be provided")
+	}
+	resolved, err := m.ResolveVersions([]string{opts.Good, opts.Bad})
+	if err != nil {
+		return nil, err
+	}
+	if len(resolved) != 2 {
+		return nil, fmt.Errorf("good and bad versions are the same")
+	}
+	good, err := parseVersionInfo(resolved[0])
+	if err != nil {
+		return nil, err
+	}
+	bad, err := parseVersionInfo(resolved[1])
+	if err != nil {
+		return nil, err
+	}
+	if !good.semver.LessThan(*bad.semver) {
+		return nil, fmt.Errorf("good version %s must be older than bad version %s", good.original, bad.original)
+	}
+
+	remotes, err := m.remoteVersions()
+	if err != nil {
+		return nil, err
+	}
+	// stable releases between good and bad, oldest first
+	var candidates []string
+	for i := len(remotes) - 1; i >= 0; i-- {
+		v := &remotes[i]
+		if v.betaSuffix != "" || !good.semver.LessThan(*v.semver) || !v.semver.LessThan(*bad.semver) {
+			continue
+		}
+		candidates = append(candidates, v.original)
+	}
+
+	res := &BisectResult{Good: good.original, Bad: bad.original}
+	fmt.Fprintf(m.Stdout, "Bisect %d releases between %s and %s...\n", len(candidates), good.original, bad.original)
+	for _, check := range []struct{ version, want string }{{good.original, BisectGood}, {bad.original, BisectBad}} {
+		step, err := m.bisectStep(res, check.version, args, opts)
+		if err != nil {
+			return res, err
+		}
+		if step.Verdict == BisectSkip && step.Err != nil {
+			return res, step.Err
+		}
+		if step.Verdict != check.want {
+			return res, fmt.Errorf("Go %s is expected to be %s but it's %s", check.version, check.want, step.Verdict)
+		}
+	}
+
+	// lo is index of the newest good release, hi is index of the oldest bad release
+	lo, hi := -1, len(candidates)
+	skipped := map[int]bool{}
+	for {
+		i := bisectMiddle(lo, hi, skipped)
+		if i < 0 {
+			break
+		}
+		step, err := m.bisectStep(res, candidates[i], args, opts)
+		if err != nil {
+			return res, err
+		}
+		switch step.Verdict {
+		case BisectGood:
+			lo = i
+		case BisectBad:
+			hi = i
+		default:
+			skipped[i] = true
+		}
+	}
+
+	res.FirstBad, res.LastGood = bad.original, good.original
+	if hi < len(candidates) {
+		res.FirstBad = candidates[hi]
+	}
+	if lo >= 0 {
+		res.LastGood = candidates[lo]
+	}
+	for i := lo + 1; i < hi; i++ {
+		res.Skipped = append(res.Skipped, candidates[i])
+	}
+	return res, nil
+}
+
+// bisectMiddle returns index of not skipped release closest to the middle of (lo, hi) range
+// or -1 if there are no releases to test.
+func bisectMiddle(lo, hi int, skipped map[int]bool) int {
+	mid := lo + (hi-lo)/2
+	for d := 0; mid-d > lo || mid+d < hi; d++ {
+		if i := mid - d; i > lo && !skipped[i] {
+			return i
+		}
+		if i := mid + d; i > lo && i < hi && !skipped[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// bisectStep installs version if it's needed and runs command with it.
+// Version is skipped if it can't be installed (step.Err is set).
+func (m *Manager) bisectStep(res *BisectResult, version string, args []string, opts BisectOpts) (*BisectStep, error) {
+	step := BisectStep{}
+	if !m.isInstalled(version) {
+		installOpts := opts.Install
+		// bisect has to test versions which are not allowed for use
+		installOpts.IgnorePolicy = true
+		if _, err := m.Install(version, installOpts); err != nil {
+			step.MatrixRun = MatrixRun{Version: version, ExitCode: -1, Err: fmt.Errorf("install of Go %s is failed: %w", version, err)}
+			step.Verdict = BisectSkip
+			fmt.Fprintf(m.Stderr, "WARNING: %v, Go %s is skipped\n", step.Err, version)
+			res.Steps = append(res.Steps, step)
+			return &res.Steps[len(res.Steps)-1], nil
+		}
+		step.Installed = true
+	}
+
+	fmt.Fprintf(m.Stdout, "Test Go %s...\n", version)
+	out := &prefixWriter{mu: &sync.Mutex{}, w: m.Stdout, prefix: "[" + version + "] "}
+	step.MatrixRun = m.runVersion(version, args, out)
+	out.Flush()
+	switch code := step.ExitCode; {
+	case code == 0:
+		step.Verdict = BisectGood
+	case code == bisectSkipCode:
+		step.Verdict = BisectSkip
+	case code > 0 && code < 128:
+		step.Verdict = BisectBad
+	default:
+		return nil, fmt.Errorf("run with Go %s is failed: %w", version, step.Err)
+	}
+	fmt.Fprintf(m.Stdout, "Go %s is %s\n", version, step.Verdict)
+	res.Steps = append(res.Steps, step)
+	return &res.Steps[len(res.Steps)-1], nil
+}
+
+// isInstalled reports whether helper tool of version exists and its SDK is downloaded.
+func (m *Manager) isInstalled(version string) bool {
+	return fileExists(filepath.Join(m.binDir(), "go"+version)) &&
+		fileExists(filepath.Join(m.sdkPath(version), unpackedMarker))
+}
@@ -0,0 +1,80 @@
+package golang_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+// failingInstallRunner fails `go install` of helper tool for version.
+type failingInstallRunner struct {
+	golang.Runner
+	version string
+}
+
+func (r failingInstallRunner) Run(cmd *golang.Command) error {
+	if cmd.Name == "go" && len(cmd.Args) == 2 && cmd.Args[0] == "install" && cmd.Args[1] == "golang.org/dl/go"+r.version+"@latest" {
+		return errors.New("fake install failure")
+	}
+	return r.Runner.Run(cmd)
+}
+
+func TestBisect(t *testing.T) {
+	tests := []struct {
+		name     string
+		firstBad string
+		lastGood string
+		skipped  []string
+		steps    []string
+	}{
+		{
+			name:     "skipped version is tested around",
+			firstBad: "1.21.5",
+			lastGood: "1.21.4",
+			steps:    []string{"1.21.0", "1.22.0", "1.21.3", "1.21.2", "1.21.4", "1.21.5"},
+		},
+		{
+			name:     "skipped version is next to first bad",
+			firstBad: "1.21.4",
+			lastGood: "1.21.2",
+			skipped:  []string{"1.21.3"},
+			steps:    []string{"1.21.0", "1.22.0", "1.21.3", "1.21.2", "1.21.4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, m := newTestEnv(t, "1.21.0", "1.21.1", "1.21.2", "1.21.3", "1.21.4", "1.21.5", "1.22.0")
+			runner := golangtest.NewFakeRunner(env)
+			runner.Fallback = golang.ExecRunner{}
+			// 1.21.3 can't be installed
+			m.Runner = failingInstallRunner{Runner: runner, version: "1.21.3"}
+			// fake toolchain is broken since firstBad
+			script := `v=$(head -n 1 "$GOROOT/VERSION"); ` +
+				`printf '%s\n%s\n' "$v" "go` + tt.firstBad + `" | sort -V -C && [ "$v" != "go` + tt.firstBad + `" ]`
+
+			res, err := m.Bisect(golang.BisectOpts{Good: "1.21.0", Bad: "1.22"}, []string{"sh", "-c", script})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.FirstBad != tt.firstBad || res.LastGood != tt.lastGood {
+				t.Errorf("first bad %s, last good %s, want %s, %s", res.FirstBad, res.LastGood, tt.firstBad, tt.lastGood)
+			}
+			if !reflect.DeepEqual(res.Skipped, tt.skipped) {
+				t.Errorf("skipped %q, want %q", res.Skipped, tt.skipped)
+			}
+			var steps []string
+			for _, s := range res.Steps {
+				steps = append(steps, s.Version)
+				if s.Version == "1.21.3" && (s.Verdict != golang.BisectSkip || s.Err == nil) {
+					t.Errorf("Go 1.21.3 verdict %s (%v), skip with install error is expected", s.Verdict, s.Err)
+				}
+			}
+			if !reflect.DeepEqual(steps, tt.steps) {
+				t.Errorf("%d steps %q, want %d steps %q", len(steps), steps, len(tt.steps), tt.steps)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package golang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Archives cache is content-addressed:
+//
+//	archives/sha256/<sha256> is archive content,
+//	archives/by-name/<archive file name> contains sha256 of the archive.
+const (
+	archivesContentDir = "sha256"
+	archivesNamesDir   = "by-name"
+)
+
+func (m *Manager) archivesCacheDir() string {
+	return filepath.Join(m.CacheDir, "archives")
+}
+
+// archiveFilename returns file name of Go release archive for goos/goarch.
+func archiveFilename(version string, goos string, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return "go" + version + "." + goos + "-" + goarch + ext
+}
+
+// urlCacheFile returns file in CacheDir keeping content fetched from url:
+// name is suffixed by hash of url, so content of other url is never used instead.
+func (m *Manager) urlCacheFile(name string, url string) string {
+	sum := sha256.Sum256([]byte(url))
+	ext := filepath.Ext(name)
+	return filepath.Join(m.CacheDir, strings.TrimSuffix(name, ext)+"-"+hex.EncodeToString(sum[:8])+ext)
+}
+
+// cachedArchive returns path of cached archive by its file name (empty if it's not cached).
+// If checksum isn't empty, cached archive must match it.
+// Corrupted archive is removed from cache, so it's downloaded again.
+func (m *Manager) cachedArchive(filename string, checksum string) (string, error) {
+	dir := m.archivesCacheDir()
+	b, err := os.ReadFile(filepath.Join(dir, archivesNamesDir, filename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	sum := strings.TrimSpace(string(b))
+	if checksum != "" && sum != checksum {
+		return "", nil
+	}
+
+	archive := filepath.Join(dir, archivesContentDir, sum)
+	// content must match its address
+	if err := verifyChecksum(archive, sum); err != nil {
+		switch {
+		case os.IsNotExist(err):
+			return "", nil
+		case errors.Is(err, errChecksumMismatch):
+			m.Logger.Warn("corrupted archive is removed from cache", "file", filename, "err", err)
+			os.Remove(archive)
+			os.Remove(filepath.Join(dir, archivesNamesDir, filename))
+			return "", nil
+		}
+		return "", err
+	}
+	return archive, nil
+}
+
+// cacheArchive moves (or copies if move is impossible) archive to cache and returns its cached path.
+func (m *Manager) cacheArchive(archive string, move bool) (string, error) {
+	dir := m.archivesCacheDir()
+	sum, err := fileSHA256(archive)
+	if err != nil {
+		return "", err
+	}
+	for _, d := range []string{archivesContentDir, archivesNamesDir} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+			return "", err
+		}
+	}
+
+	cached := filepath.Join(dir, archivesContentDir, sum)
+	if _, err := os.Stat(cached); os.IsNotExist(err) {
+		if !move || os.Rename(archive, cached) != nil {
+			if err := copyFile(archive, cached); err != nil {
+				return "", err
+			}
+		}
+	}
+	if move {
+		os.Remove(archive)
+	}
+
+	nameFile := filepath.Join(dir, archivesNamesDir, filepath.Base(archive))
+	if err := writeFileAtomic(nameFile, []byte(sum+"\n")); err != nil {
+		return "", err
+	}
+	return cached, nil
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := dst + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// offlineArchive finds release archive by its file name in fromDir (if provided) or in cache.
+// Archive found in fromDir is copied to cache.
+func (m *Manager) offlineArchive(filename string, fromDir string) (string, error) {
+	if fromDir != "" {
+		archive := filepath.Join(fromDir, filename)
+		if _, err := os.Stat(archive); err == nil {
+			return m.cacheArchive(archive, false)
+		}
+	}
+
+	archive, err := m.cachedArchive(filename, "")
+	if err != nil {
+		return "", err
+	}
+	if archive == "" {
+		return "", fmt.Errorf("%s is not found in cache", filename)
+	}
+	return archive, nil
+}
+
+// CacheUsage is disk usage of cache directory.
+type CacheUsage struct {
+	// Name is "archives" for cached release archives or isolated Go environment variable (GOCACHE, GOMODCACHE, GOPATH).
+	Name string
+	// Version is Go version of isolated directory (empty for archives).
+	Version string
+	Dir     string
+	Size    int64
+}
+
+// Caches returns disk usage of release archives cache and isolated Go environment directories.
+func (m *Manager) Caches() ([]CacheUsage, error) {
+	var caches []CacheUsage
+	if size, err := dirSize(m.archivesCacheDir()); err == nil {
+		caches = append(caches, CacheUsage{Name: "archives", Dir: m.archivesCacheDir(), Size: size})
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	versionDirs, err := os.ReadDir(m.isolationRoot())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, vd := range versionDirs {
+		if !vd.IsDir() {
+			continue
+		}
+		version := strings.TrimPrefix(vd.Name(), "go")
+		for _, name := range IsolatedVars {
+			dir := m.isolationDir(version, name)
+			size, err := dirSize(dir)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			caches = append(caches, CacheUsage{Name: name, Version: version, Dir: dir, Size: size})
+		}
+	}
+	return caches, nil
+}
+
+// CleanCacheOpts controls CleanCaches behaviour.
+type CleanCacheOpts struct {
+	// Versions limits cleaning to isolated directories of versions (all versions if empty).
+	Versions []string
+	// Archives removes cached release archives too.
+	Archives bool
+}
+
+// CleanCaches removes isolated Go environment directories (and release archives if requested),
+// returns removed caches.
+func (m *Manager) CleanCaches(opts CleanCacheOpts) ([]CacheUsage, error) {
+	caches, err := m.Caches()
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string]bool{}
+	for _, v := range opts.Versions {
+		versions[v] = true
+	}
+
+	var removed []CacheUsage
+	for _, c := range caches {
+		switch {
+		case c.Version == "" && !opts.Archives:
+			continue
+		case c.Version != "" && len(versions) > 0 && !versions[c.Version]:
+			continue
+		}
+		m.Logger.Debug("remove cache", "dir", c.Dir, "size", c.Size)
+		if err := removeAllWritable(c.Dir); err != nil {
+			return removed, fmt.Errorf("remove of %s is failed: %w", c.Dir, err)
+		}
+		removed = append(removed, c)
+	}
+
+	// remove empty version directories
+	for v := range versions {
+		os.Remove(filepath.Join(m.isolationRoot(), "go"+v))
+	}
+	if len(versions) == 0 {
+		entries, _ := os.ReadDir(m.isolationRoot())
+		for _, e := range entries {
+			os.Remove(filepath.Join(m.isolationRoot(), e.Name()))
+		}
+	}
+	return removed, nil
+}
+
+// dirSize returns total size of regular files in dir.
+func dirSize(dir string) (int64, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return 0, err
+	}
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// removeAllWritable removes dir making its subdirectories writable first
+// (module cache is read-only, so os.RemoveAll fails on it).
+func removeAllWritable(dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if info.Mode().Perm()&0700 != 0700 {
+				return os.Chmod(path, info.Mode().Perm()|0700)
+			}
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,148 @@
+package golang_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+// hostArchiveName returns file name of release archive for host platform.
+func hostArchiveName(version string) string {
+	return "go" + version + "." + runtime.GOOS + "-" + runtime.GOARCH + ".tar.gz"
+}
+
+// archiveRequests returns number of archive downloads served by srv.
+func archiveRequests(srv *golangtest.ReleaseServer) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if strings.HasSuffix(r, ".tar.gz") {
+			n++
+		}
+	}
+	return n
+}
+
+// requireInstalled fails test if SDK of version isn't installed.
+func requireInstalled(t *testing.T, env *golangtest.Env, version string) {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(env.SDKPath(version), "VERSION"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "go"+version+"\n") {
+		t.Fatalf("VERSION = %q, go%s is expected", b, version)
+	}
+	if _, err := os.Lstat(env.WrapperPath(version)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInstallOfflineFromCache(t *testing.T) {
+	env, m, srv := newTestServerEnv(t, "1.22.1")
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Uninstall("1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	if _, err := m.Install("1.22.1", golang.InstallOpts{Offline: true}); err != nil {
+		t.Fatal(err)
+	}
+	requireInstalled(t, env, "1.22.1")
+	if _, err := m.Install("1.21.3", golang.InstallOpts{Offline: true}); err == nil {
+		t.Error("offline install of not cached version error is expected")
+	}
+}
+
+func TestInstallFromDir(t *testing.T) {
+	env, m := newTestEnv(t)
+	dir := t.TempDir()
+	archive, err := golangtest.Archive("1.21.3", runtime.GOOS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hostArchiveName("1.21.3")), archive, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Install("1.21.3", golang.InstallOpts{Offline: true, FromDir: dir}); err != nil {
+		t.Fatal(err)
+	}
+	requireInstalled(t, env, "1.21.3")
+	// archive is cached
+	if err := m.Uninstall("1.21.3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, hostArchiveName("1.21.3"))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Install("1.21.3", golang.InstallOpts{Offline: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	env, m := newTestEnv(t)
+	archive, err := golangtest.Archive("1.21.3", runtime.GOOS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), hostArchiveName("1.21.3"))
+	if err := os.WriteFile(file, archive, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := m.Import(file, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Version != "1.21.3" || res.GOROOT != env.SDKPath("1.21.3") {
+		t.Errorf("imported %s to %s, want 1.21.3 to %s", res.Version, res.GOROOT, env.SDKPath("1.21.3"))
+	}
+	requireInstalled(t, env, "1.21.3")
+	fi, err := os.Stat(env.SDKPath("1.21.3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := fi.Mode().Perm(); mode != 0755 {
+		t.Errorf("SDK directory mode is %v, want %v", mode, os.FileMode(0755))
+	}
+	if _, err := m.Import(file, false); err == nil {
+		t.Error("import of installed version error is expected")
+	}
+}
+
+func TestCorruptedCachedArchiveIsDownloadedAgain(t *testing.T) {
+	env, m, srv := newTestServerEnv(t, "1.22.1")
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Uninstall("1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := filepath.Glob(filepath.Join(env.CacheDir, "archives", "sha256", "*"))
+	if err != nil || len(cached) != 1 {
+		t.Fatalf("cached archives %q, %v", cached, err)
+	}
+	if err := os.WriteFile(cached[0], []byte("corrupted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	requireInstalled(t, env, "1.22.1")
+	if n := archiveRequests(srv); n != 2 {
+		t.Errorf("archive is downloaded %d times, want 2", n)
+	}
+	if b, err := os.ReadFile(cached[0]); err != nil || string(b) == "corrupted" {
+		t.Errorf("cached archive isn't replaced: %v", err)
+	}
+}
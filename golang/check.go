@@ -0,0 +1,244 @@
+package golang
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// CheckOpts controls Check behaviour.
+type CheckOpts struct {
+	// SkipEOL disables check of end-of-life versions (it requires remote versions list).
+	SkipEOL bool
+}
+
+// Declaration is Go version declared in project.
+type Declaration struct {
+	// Location is file (with line number if it's known) or editor name.
+	Location string
+	// Kind is declaration kind ("go directive", "toolchain", "Dockerfile", "GOROOT", etc).
+	Kind    string
+	Version string
+}
+
+// CheckResult is result of Check.
+type CheckResult struct {
+	Declarations []Declaration
+	Problems     []string
+	// Warnings are non fatal issues (e.g. skipped end-of-life check).
+	Warnings []string
+}
+
+// Check collects Go versions declared in project (go.mod, go.work, .go-version,
+// Dockerfiles, CI configs, editor settings) and reports problems if they disagree,
+// reference end-of-life Go version or violate enforced team policy (violations of not enforced one are warnings). *ProblemsError is returned together with result if problems are found.
+func (m *Manager) Check(opts CheckOpts) (*CheckResult, error) {
+	refs, err := findVersionRefs(m.ProjectDir, goVersionFilePatterns)
+	if err != nil {
+		return nil, fmt.Errorf("version references search is failed: %w", err)
+	}
+	otherRefs, err := findVersionRefs(m.ProjectDir, versionRefPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("version references search is failed: %w", err)
+	}
+	refs = append(refs, otherRefs...)
+
+	res := &CheckResult{}
+	var problems []string
+	for _, e := range m.detectedEditors(m.ProjectDir) {
+		goRoot, err := e.CurrentGOROOT(m.ProjectDir)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: settings read is failed: %v", e.Name(), err))
+			continue
+		}
+		if goRoot == "" {
+			continue
+		}
+		version, err := goRootVersion(goRoot)
+		if err != nil {
+			// SDK can be missing locally, try to guess version by directory name (go1.21.3)
+			version = strings.TrimPrefix(filepath.Base(goRoot), "go")
+			if !versionRe.MatchString(version) {
+				res.Declarations = append(res.Declarations, Declaration{Location: e.Name(), Kind: "GOROOT", Version: "unknown"})
+				problems = append(problems, fmt.Sprintf("%s: version of GOROOT %s is unknown, it can't match other declarations",
+					e.Name(), goRoot))
+				continue
+			}
+		}
+		refs = append(refs, versionRef{file: e.Name(), kind: "GOROOT", value: version})
+	}
+
+	if len(refs) == 0 {
+		return res, res.result(problems)
+	}
+
+	for _, ref := range refs {
+		res.Declarations = append(res.Declarations, Declaration{Location: ref.location(), Kind: ref.kind, Version: ref.value})
+	}
+
+	// all exact declarations must agree with the reference one (toolchain directive or the first one)
+	// and must not be lower than minimum declarations (go directive)
+	var reference *versionRef
+	for i := range refs {
+		if refs[i].minimum {
+			continue
+		}
+		if reference == nil || refs[i].kind == "toolchain" {
+			reference = &refs[i]
+		}
+		if refs[i].kind == "toolchain" {
+			break
+		}
+	}
+	for _, ref := range refs {
+		if ref.minimum {
+			continue
+		}
+		if !versionsAgree(ref.value, reference.value) {
+			problems = append(problems, fmt.Sprintf("%s: %s disagrees with %s (%s)",
+				ref.location(), ref.value, reference.location(), reference.value))
+		}
+		for _, minRef := range refs {
+			if minRef.minimum && versionLess(ref.value, minRef.value) {
+				problems = append(problems, fmt.Sprintf("%s: %s is lower than %s of %s (%s)",
+					ref.location(), ref.value, minRef.kind, minRef.location(), minRef.value))
+			}
+		}
+	}
+
+	if !opts.SkipEOL {
+		eolProblems, err := m.checkEOL(refs)
+		if err != nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("end-of-life check is skipped: %v", err))
+		}
+		problems = append(problems, eolProblems...)
+	}
+
+	policy, err := m.Policy()
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		violations := checkPolicyRefs(policy, refs)
+		if policy.Enforced() {
+			problems = append(problems, violations...)
+		} else {
+			res.Warnings = append(res.Warnings, violations...)
+		}
+	}
+	return res, res.result(problems)
+}
+
+// checkPolicyRefs returns violations of team policy by declared versions.
+func checkPolicyRefs(policy *Policy, refs []versionRef) []string {
+	var violations []string
+	for _, ref := range refs {
+		version := ref.value
+		if ref.minimum {
+			// go directive declares compatibility: only major release must be allowed
+			v, err := parseVersionInfo(version)
+			if err != nil {
+				continue
+			}
+			version = majorMinor(v)
+		}
+		for _, v := range policy.Violations(version) {
+			violations = append(violations, fmt.Sprintf("%s: %s (policy %s)", ref.location(), v, policy.Source))
+		}
+	}
+	return violations
+}
+
+func (m *Manager) checkEOL(refs []versionRef) ([]string, error) {
+	remotes, err := m.remoteVersions()
+	if err != nil {
+		return nil, err
+	}
+	latest := latestStable(remotes)
+	if latest == nil {
+		return nil, fmt.Errorf("stable Go versions are not found")
+	}
+
+	var problems []string
+	for _, ref := range refs {
+		// go directive declares compatibility, old values are fine there
+		if ref.minimum {
+			continue
+		}
+		v, err := parseVersionInfo(ref.value)
+		if err != nil {
+			continue
+		}
+		if isEOL(v, latest) {
+			problems = append(problems, fmt.Sprintf("%s: Go %s is end-of-life (latest is %s)",
+				ref.location(), ref.value, latest.original))
+		}
+	}
+	return problems, nil
+}
+
+func (r *CheckResult) result(problems []string) error {
+	r.Problems = problems
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ProblemsError{Problems: problems}
+}
+
+func (r versionRef) location() string {
+	if r.line == 0 {
+		return r.file
+	}
+	return r.file + ":" + strconv.Itoa(r.line)
+}
+
+// versionParts splits version to numeric parts and pre-release suffix.
+// Example: 1.21rc1 -> [1 21], "rc1".
+func versionParts(version string) ([]int64, string) {
+	var parts []int64
+	for _, p := range strings.Split(version, ".") {
+		i := 0
+		for i < len(p) && p[i] >= '0' && p[i] <= '9' {
+			i++
+		}
+		n, err := strconv.ParseInt(p[:i], 10, 64)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+		if i < len(p) {
+			return parts, p[i:]
+		}
+	}
+	return parts, ""
+}
+
+// versionsAgree reports whether versions are equal up to precision of less precise one.
+// Example: 1.21 agrees with 1.21.3, but 1.21.2 doesn't.
+func versionsAgree(a, b string) bool {
+	aParts, aSuffix := versionParts(a)
+	bParts, bSuffix := versionParts(b)
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if aParts[i] != bParts[i] {
+			return false
+		}
+	}
+	if len(aParts) == len(bParts) && aSuffix != bSuffix {
+		return false
+	}
+	return true
+}
+
+// versionLess reports whether a < b up to precision of less precise one ignoring pre-release suffixes.
+// Example: 1.21 isn't less than 1.21.3, but 1.21.2 is.
+func versionLess(a, b string) bool {
+	aParts, _ := versionParts(a)
+	bParts, _ := versionParts(b)
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if aParts[i] != bParts[i] {
+			return aParts[i] < bParts[i]
+		}
+	}
+	return false
+}
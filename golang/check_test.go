@@ -0,0 +1,127 @@
+package golang_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+func TestCheckUnknownEditorGOROOT(t *testing.T) {
+	env, m := newTestEnv(t)
+	if err := env.WriteProjectFile("go.mod", "module example.com/app\n\ngo 1.21 // comment\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.WriteProjectFile(".vscode/settings.json", `{"go.goroot": "/opt/go"}`); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := m.Check(golang.CheckOpts{SkipEOL: true})
+	var problems *golang.ProblemsError
+	if !errors.As(err, &problems) {
+		t.Fatalf("Check error = %v, *ProblemsError is expected", err)
+	}
+	if len(problems.Problems) != 1 || !strings.Contains(problems.Problems[0], "version of GOROOT /opt/go is unknown") {
+		t.Errorf("problems = %q", problems.Problems)
+	}
+	var found bool
+	for _, d := range res.Declarations {
+		if d.Kind == "GOROOT" && d.Version == "unknown" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("declarations %+v don't contain unknown GOROOT", res.Declarations)
+	}
+}
+
+func TestUseAndCheckAgreeOnGoMod(t *testing.T) {
+	env, m := newTestEnv(t)
+	m.Prompter = alwaysYes
+	runner := golangtest.NewFakeRunner(env)
+	m.Runner = runner
+	m.Editors = nil
+	if err := env.AddSDK("1.21.3"); err != nil {
+		t.Fatal(err)
+	}
+
+	// go directive with patch release is already up to date
+	if err := env.WriteProjectFile("go.mod", "module example.com/app\n\ngo 1.21.3\n"); err != nil {
+		t.Fatal(err)
+	}
+	res, err := m.UseVersion(env.GoBinLink, "1.21.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GoMod != "" {
+		t.Errorf("go.mod is patched to %s", res.GoMod)
+	}
+	for _, call := range runner.Calls() {
+		if strings.HasPrefix(call, "go mod") {
+			t.Errorf("unexpected call %q", call)
+		}
+	}
+	assertCheckPasses(t, m, "1.21.3")
+
+	// outdated go directive is patched, check reads the same value
+	if err := env.WriteProjectFile("go.mod", "module example.com/app\n\ngo 1.20 // comment\n"); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = m.UseVersion(env.GoBinLink, "1.21.3"); err != nil {
+		t.Fatal(err)
+	}
+	if res.GoMod != "1.21" {
+		t.Errorf("go.mod is patched to %q, want 1.21", res.GoMod)
+	}
+	goMod, err := env.ReadProjectFile("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireContains(t, goMod, "\ngo 1.21 // comment\n")
+	assertCheckPasses(t, m, "1.21")
+}
+
+// assertCheckPasses checks that Check passes and reads go directive of go.mod as version.
+func assertCheckPasses(t *testing.T, m *golang.Manager, version string) {
+	t.Helper()
+	res, err := m.Check(golang.CheckOpts{SkipEOL: true})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(res.Declarations) != 1 || res.Declarations[0].Kind != "go directive" || res.Declarations[0].Version != version {
+		t.Errorf("declarations = %+v, want go directive %s", res.Declarations, version)
+	}
+}
+
+func TestCheckMinimumVersionPrecision(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerfile string
+		wantLower  bool
+	}{
+		{"major release of minimum", "FROM golang:1.21 AS build\n", false},
+		{"patch release of minimum", "FROM golang:1.21.3\n", false},
+		{"newer patch release", "FROM golang:1.21.5-alpine\n", false},
+		{"older patch release", "FROM golang:1.21.2\n", true},
+		{"older major release", "FROM golang:1.20\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, m := newTestEnv(t)
+			if err := env.WriteProjectFile("go.mod", "module example.com/app\n\ngo 1.21.3\n"); err != nil {
+				t.Fatal(err)
+			}
+			if err := env.WriteProjectFile("Dockerfile", tt.dockerfile); err != nil {
+				t.Fatal(err)
+			}
+			_, err := m.Check(golang.CheckOpts{SkipEOL: true})
+			var problems *golang.ProblemsError
+			lower := errors.As(err, &problems) && strings.Contains(strings.Join(problems.Problems, "\n"), "is lower than go directive")
+			if lower != tt.wantLower {
+				t.Errorf("error = %v, lower than go directive problem expected: %v", err, tt.wantLower)
+			}
+		})
+	}
+}
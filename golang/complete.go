@@ -0,0 +1,61 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CompleteVersions returns versions for shell completion: installed versions and, if remote is set,
+// "stable" alias, major releases and versions from cached releases index (newest first).
+// Network isn't used, so completion is fast and works offline.
+func (m *Manager) CompleteVersions(remote bool) ([]string, error) {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	// installed versions are listed without running go<version> binaries (unlike installedVersions)
+	wrappers, err := filepath.Glob(filepath.Join(m.binDir(), "go1.*"))
+	if err != nil {
+		return nil, err
+	}
+	var installed versionList
+	for _, wrapper := range wrappers {
+		v, err := parseVersionInfo(filepath.Base(wrapper)[2:])
+		if err != nil {
+			continue
+		}
+		// golang.org/dl helper tool without downloaded SDK
+		fi, err := os.Lstat(wrapper)
+		if err != nil || (fi.Mode().IsRegular() && !fileExists(filepath.Join(m.sdkPath(v.original), unpackedMarker))) {
+			continue
+		}
+		installed = append(installed, *v)
+	}
+	installed.Sort()
+	for _, v := range installed {
+		add(v.original)
+	}
+	if !remote {
+		return names, nil
+	}
+
+	releases, err := m.cachedReleaseIndex()
+	if err != nil || releases == nil {
+		return names, err
+	}
+	add("stable")
+	remotes := m.releaseVersions(releases)
+	for _, v := range remotes {
+		add(fmt.Sprintf("%d.%d", v.semver.Major, v.semver.Minor))
+	}
+	for _, v := range remotes {
+		add(v.original)
+	}
+	return names, nil
+}
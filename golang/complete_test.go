@@ -0,0 +1,50 @@
+package golang_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompleteVersions(t *testing.T) {
+	env, m := newTestEnv(t, "1.21.3", "1.22.0", "1.22.1")
+	for _, v := range []string{"1.22.1", "1.21.3"} {
+		if err := env.AddSDK(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// helper tool without downloaded SDK isn't installed version
+	if err := env.AddWrapper("1.20.14"); err != nil {
+		t.Fatal(err)
+	}
+	// SDK registered by import is symlink
+	if err := os.Symlink(filepath.Join(env.SDKPath("1.21.3"), "bin", "go"), env.WrapperPath("1.19.13")); err != nil {
+		t.Fatal(err)
+	}
+
+	installed := []string{"1.22.1", "1.21.3", "1.19.13"}
+	for _, remote := range []bool{false, true} {
+		// releases index isn't fetched by completion
+		versions, err := m.CompleteVersions(remote)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(versions, installed) {
+			t.Errorf("remote %v without cached index: %q, want %q", remote, versions, installed)
+		}
+	}
+
+	// releases index is cached by resolve
+	if _, err := m.ResolveVersions([]string{"stable"}); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := m.CompleteVersions(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(installed, "stable", "1.22", "1.21", "1.22.0")
+	if !reflect.DeepEqual(versions, want) {
+		t.Errorf("versions %q, want %q", versions, want)
+	}
+}
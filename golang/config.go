@@ -0,0 +1,106 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Config is golangver configuration, it's read from $XDG_CONFIG_HOME/golangver/config.json
+// (see os.UserConfigDir for other platforms).
+type Config struct {
+	// Mirror is base URL of Go downloads mirror (mirror of https://dl.google.com/go/),
+	// it must serve archives at <Mirror>/<archive>.
+	Mirror string `json:"mirror,omitempty"`
+	// IndexURL is URL of Go releases index (https://go.dev/dl/?mode=json&include=all by default),
+	// e.g. proxy of go.dev if it isn't reachable.
+	IndexURL string `json:"index_url,omitempty"`
+	// CABundle is path to PEM file with additional CA certificates for HTTPS connections.
+	CABundle string `json:"ca_bundle,omitempty"`
+	// Isolate maps Go version ("1.17.6"), major release ("1.17") or "*" (any version)
+	// to Go environment variables (GOCACHE, GOMODCACHE, GOPATH) set to version-specific directory.
+	Isolate map[string][]string `json:"isolate,omitempty"`
+	// Policy is path or URL of team policy file (see Policy), it has priority over policy file in project root.
+	Policy string `json:"policy,omitempty"`
+	// IndexTTL is time cached releases index is used without fetch ("1h" by default, see time.ParseDuration).
+	IndexTTL string `json:"index_ttl,omitempty"`
+	// ModuleProxy is base URL of Go module proxy golangver releases are checked in by self-update.
+	ModuleProxy string `json:"module_proxy,omitempty"`
+	// UpdateNotice enables notice about new golangver release on other commands (checked once a day).
+	UpdateNotice bool `json:"update_notice,omitempty"`
+}
+
+// ConfigPath returns path of configuration file.
+func ConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("user config dir resolving is failed: %w", err)
+	}
+	return filepath.Join(dir, "golangver", "config.json"), nil
+}
+
+// LoadConfig reads configuration file, returns empty config if file doesn't exist.
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+	file, err := ConfigPath()
+	if err != nil {
+		return cfg, nil
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("%s parsing is failed: %w", file, err)
+	}
+	return cfg, nil
+}
+
+// ApplyConfig configures downloads: mirror URL and HTTP client.
+func (m *Manager) ApplyConfig(cfg *Config) error {
+	if cfg.Mirror != "" {
+		m.setMirror(cfg.Mirror)
+	}
+	if cfg.IndexURL != "" {
+		m.ReleaseIndexURL = cfg.IndexURL
+	}
+	for key, vars := range cfg.Isolate {
+		for _, name := range vars {
+			if !isIsolatedVar(name) {
+				return fmt.Errorf("isolate %q: unsupported variable %s (supported: %s)",
+					key, name, strings.Join(IsolatedVars, ", "))
+			}
+		}
+	}
+	if len(cfg.Isolate) > 0 {
+		m.Isolate = cfg.Isolate
+	}
+	if cfg.Policy != "" {
+		m.PolicySource = cfg.Policy
+	}
+	if cfg.ModuleProxy != "" {
+		m.ModuleProxy = cfg.ModuleProxy
+	}
+	if cfg.IndexTTL != "" {
+		ttl, err := time.ParseDuration(cfg.IndexTTL)
+		if err != nil || ttl < 0 {
+			return fmt.Errorf("index_ttl %q: duration like 30m or 6h is expected", cfg.IndexTTL)
+		}
+		m.IndexTTL = ttl
+	}
+
+	client, err := newHTTPClient(cfg.CABundle, m.Logger)
+	if err != nil {
+		return err
+	}
+	m.HTTPClient = client
+
+	m.Logger.Debug("downloads are configured", "index", m.ReleaseIndexURL, "base", m.DownloadBaseURL)
+	return nil
+}
@@ -0,0 +1,62 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+// unifiedDiff returns unified diff of lines replaced in place.
+// Both slices must have the same length (lines aren't added or removed).
+func unifiedDiff(name string, oldLines, newLines []string) string {
+	var changed []int
+	for i := range oldLines {
+		if oldLines[i] != newLines[i] {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", name, name)
+	for i := 0; i < len(changed); {
+		// merge changes with overlapping context into one hunk
+		j := i
+		for j+1 < len(changed) && changed[j+1]-changed[j] <= 2*diffContext {
+			j++
+		}
+		start := changed[i] - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := changed[j] + diffContext + 1
+		if end > len(oldLines) {
+			end = len(oldLines)
+		}
+
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1, end-start)
+		for k := start; k < end; {
+			if oldLines[k] == newLines[k] {
+				fmt.Fprintf(&out, " %s\n", oldLines[k])
+				k++
+				continue
+			}
+			run := k
+			for run < end && oldLines[run] != newLines[run] {
+				run++
+			}
+			for _, line := range oldLines[k:run] {
+				fmt.Fprintf(&out, "-%s\n", line)
+			}
+			for _, line := range newLines[k:run] {
+				fmt.Fprintf(&out, "+%s\n", line)
+			}
+			k = run
+		}
+		i = j + 1
+	}
+	return out.String()
+}
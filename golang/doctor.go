@@ -0,0 +1,114 @@
+package golang
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Editor settings statuses reported by Doctor.
+const (
+	EditorOK       = "OK"
+	EditorMismatch = "MISMATCH"
+	EditorNotSet   = "-"
+)
+
+// EditorStatus is GOROOT setting of editor detected in project.
+type EditorStatus struct {
+	Editor string
+	// GOROOT is value from settings (empty if it's not set).
+	GOROOT string
+	// Status is one of EditorOK, EditorMismatch or EditorNotSet.
+	Status string
+	// Err is settings read error.
+	Err error
+}
+
+// DoctorResult is result of Doctor.
+type DoctorResult struct {
+	Link   string
+	Target string
+	GOROOT string
+	// Editors are editors detected in project.
+	Editors []EditorStatus
+	// Manifest is manifest of GOROOT recorded at install time (nil if SDK doesn't have it).
+	Manifest *Manifest
+	// SDKDiff is difference between GOROOT files and Manifest.
+	SDKDiff *SDKDiff
+	// Lock is lock file of project (nil if it doesn't exist).
+	Lock *Lock
+}
+
+// Doctor reports problems of Go setup in current project:
+// editors which point at GOROOT not matching to linkPath symlink target,
+// GOROOT files modified after install and GOROOT not matching to lock file of project.
+// *ProblemsError is returned together with result if problems are found.
+func (m *Manager) Doctor(linkPath string) (*DoctorResult, error) {
+	currentTarget, err := m.goBinCheckSymlink(linkPath)
+	if err != nil {
+		return nil, fmt.Errorf("check symlink %s is failed: %w", linkPath, err)
+	}
+	if currentTarget == "" {
+		return nil, fmt.Errorf("symlink %s not found", linkPath)
+	}
+	goRoot := filepath.Dir(filepath.Dir(currentTarget))
+	res := &DoctorResult{Link: linkPath, Target: currentTarget, GOROOT: goRoot}
+
+	var problems []string
+	for _, e := range m.detectedEditors(m.ProjectDir) {
+		current, err := e.CurrentGOROOT(m.ProjectDir)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s settings read is failed: %v", e.Name(), err))
+			res.Editors = append(res.Editors, EditorStatus{Editor: e.Name(), Err: err})
+			continue
+		}
+		status := EditorOK
+		switch {
+		case current == "":
+			status = EditorNotSet
+		case !sameGOROOT(current, goRoot):
+			problems = append(problems, fmt.Sprintf("%s uses %s", e.Name(), current))
+			status = EditorMismatch
+		}
+		res.Editors = append(res.Editors, EditorStatus{Editor: e.Name(), GOROOT: current, Status: status})
+	}
+
+	if res.Manifest, res.SDKDiff, err = m.checkSDK(goRoot); err != nil {
+		problems = append(problems, fmt.Sprintf("SDK files check is failed: %v", err))
+	} else if res.SDKDiff != nil && !res.SDKDiff.Empty() {
+		problems = append(problems, fmt.Sprintf("SDK %s is modified after install: %s (run `golangver verify --repair %s`)",
+			goRoot, res.SDKDiff, res.Manifest.Version))
+	}
+	if res.Lock, err = m.readLock(); err != nil {
+		problems = append(problems, err.Error())
+	} else if res.Lock != nil {
+		problems = append(problems, lockProblems(res.Lock, goRoot, res.Manifest)...)
+	}
+
+	if len(problems) > 0 {
+		return res, &ProblemsError{Problems: problems}
+	}
+	return res, nil
+}
+
+// lockProblems reports if GOROOT isn't installed from archive pinned by lock.
+func lockProblems(lock *Lock, goRoot string, mf *Manifest) []string {
+	version, err := goRootVersion(goRoot)
+	if err != nil {
+		return []string{fmt.Sprintf("version of %s detection is failed: %v", goRoot, err)}
+	}
+	if version != lock.Version {
+		return []string{fmt.Sprintf("current Go %s doesn't match %s (%s)", version, LockFile, lock.Version)}
+	}
+	platform := hostPlatform()
+	locked, ok := lock.Archives[platform]
+	switch {
+	case !ok:
+		return []string{fmt.Sprintf("%s doesn't pin archive for %s", LockFile, platform)}
+	case mf == nil:
+		return []string{fmt.Sprintf("SDK %s doesn't have manifest, it can't be verified against %s", goRoot, LockFile)}
+	case mf.ArchiveSHA256 != locked.SHA256:
+		return []string{fmt.Sprintf("SDK %s is installed from archive with sha256 %s, %s pins %s",
+			goRoot, mf.ArchiveSHA256, LockFile, locked.SHA256)}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package golang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	downloadRetries = 5
+	downloadBackoff = time.Second
+)
+
+// downloader downloads files with progress reporting,
+// resume of partially downloaded files (.part) and retries.
+type downloader struct {
+	client *http.Client
+	logger *slog.Logger
+	out    io.Writer
+	// retries is number of retries after failed attempt.
+	retries int
+	// backoff is delay before the first retry, it's doubled for every next retry.
+	backoff time.Duration
+}
+
+func (m *Manager) newDownloader(out io.Writer) *downloader {
+	return &downloader{
+		client:  m.HTTPClient,
+		logger:  m.Logger,
+		out:     out,
+		retries: downloadRetries,
+		backoff: downloadBackoff,
+	}
+}
+
+// errChecksumMismatch is returned if downloaded file doesn't match expected checksum.
+var errChecksumMismatch = errors.New("checksum mismatch")
+
+// download downloads url to dest and checks its sha256 checksum (if provided).
+// Data is written to dest + ".part" file which is resumed on retries and next runs.
+func (d *downloader) download(url string, dest string, size int64, checksum string) error {
+	part := dest + ".part"
+	var err error
+	for attempt := 0; attempt <= d.retries; attempt++ {
+		if attempt > 0 {
+			delay := d.backoff << (attempt - 1)
+			fmt.Fprintf(d.out, "download is failed: %v, retry in %s...\n", err, delay)
+			time.Sleep(delay)
+		}
+
+		if err = d.fetch(url, part, size); err != nil {
+			var statusErr *statusError
+			if errors.As(err, &statusErr) && !statusErr.temporary() {
+				break
+			}
+			continue
+		}
+		if err = verifyChecksum(part, checksum); err != nil {
+			// partial file can't be resumed anymore
+			os.Remove(part)
+			continue
+		}
+		return os.Rename(part, dest)
+	}
+	return fmt.Errorf("download of %s is failed: %w", url, err)
+}
+
+// fetch downloads url to file resuming it from the current file size.
+func (d *downloader) fetch(url string, file string, size int64) error {
+	var offset int64
+	if fi, err := os.Stat(file); err == nil {
+		offset = fi.Size()
+	}
+	if size > 0 && offset == size {
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+	d.logger.Debug("http request", "method", req.Method, "url", url, "offset", offset)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	d.logger.Debug("http response", "url", url, "status", resp.Status, "length", resp.ContentLength)
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// server doesn't support ranges, start from scratch
+		offset = 0
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		// file is complete or broken, checksum verification decides
+		return nil
+	default:
+		return &statusError{status: resp.Status, code: resp.StatusCode}
+	}
+
+	total := size
+	if total <= 0 && resp.ContentLength > 0 {
+		total = offset + resp.ContentLength
+	}
+
+	f, err := os.OpenFile(file, flags, 0644)
+	if err != nil {
+		return err
+	}
+	progress := newProgressWriter(d.out, path.Base(url), offset, total)
+	_, err = io.Copy(f, io.TeeReader(resp.Body, progress))
+	progress.Done()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// statusError is unexpected HTTP response status.
+type statusError struct {
+	status string
+	code   int
+}
+
+func (e *statusError) Error() string {
+	return "unexpected response: " + e.status
+}
+
+// temporary reports whether request can succeed on retry:
+// client errors (like 404 of mistyped version) are permanent except timeout and rate limiting.
+func (e *statusError) temporary() bool {
+	return e.code >= 500 || e.code == http.StatusRequestTimeout || e.code == http.StatusTooManyRequests
+}
+
+// verifyChecksum checks sha256 of file (does nothing if checksum is empty).
+func verifyChecksum(file string, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	sum, err := fileSHA256(file)
+	if err != nil {
+		return err
+	}
+	if sum != checksum {
+		return fmt.Errorf("%w: %s sha256 is %s, expected %s", errChecksumMismatch, file, sum, checksum)
+	}
+	return nil
+}
+
+func fileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
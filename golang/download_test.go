@@ -0,0 +1,120 @@
+package golang
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDownloadResumesInterruptedBody(t *testing.T) {
+	data := bytes.Repeat([]byte("golangver"), 10000)
+	cut := len(data) / 3
+	var (
+		mu     sync.Mutex
+		ranges []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, req.Header.Get("Range"))
+		first := len(ranges) == 1
+		mu.Unlock()
+		if first {
+			// announce full body, send its part and drop connection
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.WriteHeader(http.StatusOK)
+			w.Write(data[:cut])
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		http.ServeContent(w, req, "go.tar.gz", time.Time{}, bytes.NewReader(data))
+	}))
+	defer srv.Close()
+
+	d := &downloader{
+		client:  srv.Client(),
+		logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		out:     io.Discard,
+		retries: 2,
+	}
+	dest := filepath.Join(t.TempDir(), "go.tar.gz")
+	sum := sha256.Sum256(data)
+	if err := d.download(srv.URL+"/go.tar.gz", dest, int64(len(data)), hex.EncodeToString(sum[:])); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"", "bytes=" + strconv.Itoa(cut) + "-"}
+	if len(ranges) != len(want) || ranges[0] != want[0] || ranges[1] != want[1] {
+		t.Errorf("Range headers = %q, want %q", ranges, want)
+	}
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("downloaded %d bytes differ from served %d bytes", len(got), len(data))
+	}
+	if _, err := os.Stat(dest + ".part"); !os.IsNotExist(err) {
+		t.Errorf(".part file is left: %v", err)
+	}
+}
+
+func TestDownloadRetriesTemporaryErrorsOnly(t *testing.T) {
+	tests := []struct {
+		status   int
+		requests int
+	}{
+		{http.StatusNotFound, 1},
+		{http.StatusForbidden, 1},
+		{http.StatusTooManyRequests, 3},
+		{http.StatusRequestTimeout, 3},
+		{http.StatusServiceUnavailable, 3},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				requests int
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				mu.Lock()
+				requests++
+				mu.Unlock()
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			d := &downloader{
+				client:  srv.Client(),
+				logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+				out:     io.Discard,
+				retries: 2,
+				backoff: time.Millisecond,
+			}
+			err := d.download(srv.URL+"/go.tar.gz", filepath.Join(t.TempDir(), "go.tar.gz"), 0, "")
+			if err == nil {
+				t.Fatal("download error is expected")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if requests != tt.requests {
+				t.Errorf("%d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package golang
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// EditorIntegration is implemented by IDEs and editors which pin Go SDK (GOROOT)
+// in project settings.
+type EditorIntegration interface {
+	// Name returns human readable editor name.
+	Name() string
+	// Detect reports whether project in projectDir has settings of the editor.
+	Detect(projectDir string) bool
+	// CurrentGOROOT returns GOROOT from project settings (empty string if it's not set).
+	CurrentGOROOT(projectDir string) (string, error)
+	// Apply sets GOROOT in project settings and returns written files.
+	Apply(projectDir string, goRoot string) ([]FileWrite, error)
+}
+
+// applyNoticer is optionally implemented by EditorIntegration
+// which requires user action after settings are patched.
+type applyNoticer interface {
+	ApplyNotice() string
+}
+
+// DefaultEditors returns editor integrations supported out of the box,
+// homeDir is used for expansion of home directory references in settings.
+func DefaultEditors(homeDir string) []EditorIntegration {
+	return []EditorIntegration{
+		ideaIntegration{homeDir: homeDir},
+		vscodeIntegration(homeDir),
+		zedIntegration(homeDir),
+		sublimeIntegration(homeDir),
+		helixIntegration(homeDir),
+		neovimIntegration(homeDir),
+	}
+}
+
+// EditorChange is result of editor settings patch.
+type EditorChange struct {
+	Editor string
+	// Previous is GOROOT from settings before patch.
+	Previous string
+	Files    []FileWrite
+	// Notice is editor specific note for user (e.g. project reopening is required).
+	Notice string
+}
+
+// detectedEditors returns editor integrations which have settings in projectDir.
+func (m *Manager) detectedEditors(projectDir string) []EditorIntegration {
+	var found []EditorIntegration
+	for _, e := range m.Editors {
+		if e.Detect(projectDir) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func sameGOROOT(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// patchEditors suggests to set goRoot in settings of every detected editor.
+func (m *Manager) patchEditors(goRoot string) ([]EditorChange, error) {
+	var changes []EditorChange
+	for _, e := range m.detectedEditors(m.ProjectDir) {
+		current, err := e.CurrentGOROOT(m.ProjectDir)
+		if err != nil {
+			return changes, fmt.Errorf("%s settings read is failed: %w", e.Name(), err)
+		}
+		m.Logger.Debug("editor settings are detected", "editor", e.Name(), "goroot", current)
+		if current != "" && sameGOROOT(current, goRoot) {
+			continue
+		}
+
+		fmt.Fprintf(m.Stdout, "\n%s settings are detected\n", e.Name())
+		fmt.Fprintf(m.Stdout, "  current value: %s\n", current)
+		yes, err := m.Prompter.Confirm(fmt.Sprintf(
+			"Do you want to set Go SDK = %s?", goRoot), false)
+		if err != nil {
+			return changes, err
+		}
+		if !yes {
+			continue
+		}
+		files, err := e.Apply(m.ProjectDir, goRoot)
+		if err != nil {
+			return changes, fmt.Errorf("patch %s settings is failed: %w", e.Name(), err)
+		}
+		m.Logger.Debug("editor settings are patched", "editor", e.Name(), "goroot", goRoot, "files", len(files))
+
+		change := EditorChange{Editor: e.Name(), Previous: current, Files: files}
+		if n, ok := e.(applyNoticer); ok {
+			change.Notice = n.ApplyNotice()
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
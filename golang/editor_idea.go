@@ -0,0 +1,375 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const ideaDir = ".idea"
+
+// ideaFiles are IDEA project files which can contain GOROOT component.
+var ideaFiles = []string{"workspace.xml", "misc.xml"}
+
+// ideaIntegration patches GOROOT component in GoLand/IDEA project files.
+type ideaIntegration struct {
+	// homeDir is substituted by $USER_HOME$ macro like IDEA does.
+	homeDir string
+}
+
+func (ideaIntegration) Name() string {
+	return "GoLand/IDEA"
+}
+
+func (ideaIntegration) Detect(projectDir string) bool {
+	_, err := os.ReadDir(filepath.Join(projectDir, ideaDir))
+	return err == nil
+}
+
+func (i ideaIntegration) CurrentGOROOT(projectDir string) (string, error) {
+	for _, name := range ideaFiles {
+		url, found, err := ideaGOROOTURL(filepath.Join(projectDir, ideaDir, name))
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return i.expandUserHomeDirInPathLikeIDEADoes(strings.TrimPrefix(url, "file://")), nil
+		}
+	}
+	return "", nil
+}
+
+func (i ideaIntegration) Apply(projectDir string, goRoot string) ([]FileWrite, error) {
+	setGoRoot := "file://" + i.substituteUserHomeDirInPathLikeIDEADoes(goRoot)
+	var (
+		writes  []FileWrite
+		patched bool
+	)
+	for _, name := range ideaFiles {
+		file := filepath.Join(projectDir, ideaDir, name)
+		b, err := os.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if len(bytes.TrimSpace(b)) == 0 {
+			// IDEA creates empty files, empty workspace.xml is filled below
+			continue
+		}
+		doc, err := scanIDEAFile(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s decoding is failed: %w", file, err)
+		}
+		if doc.component("GOROOT") == nil {
+			continue
+		}
+		w, err := patchIDEAFile(file, b, doc, setGoRoot)
+		if err != nil {
+			return nil, err
+		}
+		if w != nil {
+			writes = append(writes, *w)
+		}
+		patched = true
+	}
+
+	if !patched {
+		// GOROOT component is missing: add it to workspace.xml
+		file := filepath.Join(projectDir, ideaDir, "workspace.xml")
+		b, err := os.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(b)) == 0 {
+			b = []byte(ideaEmptyWorkspace)
+		}
+		doc, err := scanIDEAFile(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s decoding is failed: %w", file, err)
+		}
+		w, err := patchIDEAFile(file, b, doc, setGoRoot)
+		if err != nil {
+			return nil, err
+		}
+		if w != nil {
+			writes = append(writes, *w)
+		}
+	}
+	return writes, nil
+}
+
+// ApplyNotice returns notice shown after settings are patched.
+func (ideaIntegration) ApplyNotice() string {
+	return "project reopening in IDEA is required"
+}
+
+const ideaEmptyWorkspace = `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+</project>
+`
+
+func isIDEAGOROOTComponent(el xml.StartElement) bool {
+	return ideaComponentName(el) == "GOROOT"
+}
+
+func ideaComponentName(el xml.StartElement) string {
+	if el.Name.Local != "component" {
+		return ""
+	}
+	for _, attr := range el.Attr {
+		if attr.Name.Local == "name" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// ideaGOROOTURL returns url attribute of GOROOT component from IDEA project file.
+func ideaGOROOTURL(file string) (string, bool, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("%s decoding is failed: %w", file, err)
+		}
+
+		el, ok := token.(xml.StartElement)
+		if !ok || !isIDEAGOROOTComponent(el) {
+			continue
+		}
+		for _, attr := range el.Attr {
+			if attr.Name.Local == "url" {
+				return attr.Value, true, nil
+			}
+		}
+	}
+}
+
+// ideaComponent is top level <component> element of IDEA project file.
+type ideaComponent struct {
+	name string
+	// tagStart and tagEnd are offsets of the start tag.
+	tagStart, tagEnd int
+	// end is offset of the element end (end of the end tag).
+	end int
+	url string
+}
+
+// ideaDocument holds byte offsets of IDEA project file elements.
+type ideaDocument struct {
+	components []ideaComponent
+	// projectEnd is offset of </project> end tag.
+	projectEnd int
+	// indent is indentation of components.
+	indent string
+}
+
+func (d *ideaDocument) component(name string) *ideaComponent {
+	for i := range d.components {
+		if d.components[i].name == name {
+			return &d.components[i]
+		}
+	}
+	return nil
+}
+
+// scanIDEAFile finds offsets of <component> elements in IDEA project file.
+// It doesn't modify anything, so the file can be patched in place.
+func scanIDEAFile(b []byte) (*ideaDocument, error) {
+	doc := &ideaDocument{projectEnd: -1, indent: "  "}
+	decoder := xml.NewDecoder(bytes.NewReader(b))
+	var (
+		depth   int
+		current *ideaComponent
+	)
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth != 2 || v.Name.Local != "component" {
+				break
+			}
+			if len(doc.components) == 0 {
+				doc.indent = lineIndent(b, offset)
+			}
+			c := ideaComponent{
+				name:     ideaComponentName(v),
+				tagStart: offset,
+				tagEnd:   int(decoder.InputOffset()),
+			}
+			for _, attr := range v.Attr {
+				if attr.Name.Local == "url" {
+					c.url = attr.Value
+				}
+			}
+			doc.components = append(doc.components, c)
+			current = &doc.components[len(doc.components)-1]
+		case xml.EndElement:
+			depth--
+			switch {
+			case depth == 1 && current != nil:
+				current.end = int(decoder.InputOffset())
+				current = nil
+			case depth == 0 && v.Name.Local == "project":
+				doc.projectEnd = offset
+			}
+		}
+	}
+	if doc.projectEnd < 0 {
+		return nil, fmt.Errorf("<project> element is not found")
+	}
+	return doc, nil
+}
+
+// lineIndent returns whitespace between line start and offset.
+func lineIndent(b []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(b[:offset], '\n') + 1
+	indent := b[lineStart:offset]
+	if len(bytes.TrimLeft(indent, " \t")) != 0 {
+		return ""
+	}
+	return string(indent)
+}
+
+// xmlEdit replaces b[start:end] with text.
+type xmlEdit struct {
+	start, end int
+	text       string
+}
+
+func applyXMLEdits(b []byte, edits []xmlEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+	out := append([]byte(nil), b...)
+	for _, e := range edits {
+		out = append(out[:e.start:e.start], append([]byte(e.text), out[e.end:]...)...)
+	}
+	return out
+}
+
+var (
+	xmlURLAttrRe   = regexp.MustCompile(`\burl\s*=\s*("[^"]*"|'[^']*')`)
+	xmlNameAttrRe  = regexp.MustCompile(`\bname\s*=\s*("[^"]*"|'[^']*')`)
+	xmlValueAttrRe = regexp.MustCompile(`\b(?:url|value|path)\s*=\s*("[^"]*"|'[^']*')`)
+)
+
+// patchIDEAFile sets url of GOROOT component changing only url attribute bytes.
+// If GOROOT component is missing it's added before </project>.
+// URLs in GOPATH and GoLibraries components pointing inside old GOROOT are updated too.
+// Returns nil if file is up to date.
+func patchIDEAFile(file string, b []byte, doc *ideaDocument, url string) (*FileWrite, error) {
+	var edits []xmlEdit
+	goRootComponent := doc.component("GOROOT")
+	if goRootComponent == nil {
+		text := doc.indent + `<component name="GOROOT" url="` + xmlAttrEscape(url) + `" />` + "\n"
+		lineStart := bytes.LastIndexByte(b[:doc.projectEnd], '\n') + 1
+		if lineIndent(b, doc.projectEnd) == "" && lineStart != doc.projectEnd {
+			// </project> isn't at line start
+			text = "\n" + text
+			lineStart = doc.projectEnd
+		}
+		edits = append(edits, xmlEdit{start: lineStart, end: lineStart, text: text})
+	} else {
+		if goRootComponent.url == url {
+			return nil, nil
+		}
+		tag := b[goRootComponent.tagStart:goRootComponent.tagEnd]
+		if m := xmlURLAttrRe.FindSubmatchIndex(tag); m != nil {
+			edits = append(edits, xmlEdit{
+				start: goRootComponent.tagStart + m[2] + 1,
+				end:   goRootComponent.tagStart + m[3] - 1,
+				text:  xmlAttrEscape(url),
+			})
+		} else if m := xmlNameAttrRe.FindSubmatchIndex(tag); m != nil {
+			pos := goRootComponent.tagStart + m[1]
+			edits = append(edits, xmlEdit{start: pos, end: pos, text: ` url="` + xmlAttrEscape(url) + `"`})
+		}
+
+		if oldURL := goRootComponent.url; oldURL != "" {
+			for _, name := range []string{"GOPATH", "GoLibraries"} {
+				c := doc.component(name)
+				if c == nil {
+					continue
+				}
+				edits = append(edits, replaceURLPrefix(b, c, oldURL, url)...)
+			}
+		}
+	}
+
+	backup, err := writeFileWithBackup(file, applyXMLEdits(b, edits))
+	if err != nil {
+		return nil, err
+	}
+	return &FileWrite{File: file, Backup: backup, Created: backup == ""}, nil
+}
+
+// replaceURLPrefix returns edits replacing oldURL prefix of attribute values inside component c.
+func replaceURLPrefix(b []byte, c *ideaComponent, oldURL, newURL string) []xmlEdit {
+	var edits []xmlEdit
+	oldURL = strings.TrimSuffix(oldURL, "/")
+	for _, m := range xmlValueAttrRe.FindAllSubmatchIndex(b[c.tagStart:c.end], -1) {
+		start := c.tagStart + m[2] + 1
+		end := c.tagStart + m[3] - 1
+		value := string(b[start:end])
+		if value != oldURL && !strings.HasPrefix(value, oldURL+"/") {
+			continue
+		}
+		edits = append(edits, xmlEdit{
+			start: start,
+			end:   end,
+			text:  xmlAttrEscape(strings.TrimSuffix(newURL, "/") + value[len(oldURL):]),
+		})
+	}
+	return edits
+}
+
+func xmlAttrEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func (i ideaIntegration) substituteUserHomeDirInPathLikeIDEADoes(path string) string {
+	homedir := i.homeDir
+	if homedir == "" || !strings.HasPrefix(path, homedir) {
+		return path
+	}
+	return "$USER_HOME$" + path[len(homedir):]
+}
+
+func (i ideaIntegration) expandUserHomeDirInPathLikeIDEADoes(path string) string {
+	const userHome = "$USER_HOME$"
+	if i.homeDir == "" || !strings.HasPrefix(path, userHome) {
+		return path
+	}
+	return i.homeDir + path[len(userHome):]
+}
@@ -0,0 +1,169 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIDEAApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		workspace string
+		misc      string
+		want      string
+		wantMisc  string
+	}{
+		{
+			name:      "empty workspace",
+			workspace: "",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="GOROOT" url="file://$USER_HOME$/sdk/go1.22.1" />
+</project>
+`,
+		},
+		{
+			name: "missing component",
+			workspace: `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+    <component name="ChangeListManager">
+        <list default="true" id="1" name="Changes" comment="" />
+    </component>
+</project>
+`,
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+    <component name="ChangeListManager">
+        <list default="true" id="1" name="Changes" comment="" />
+    </component>
+    <component name="GOROOT" url="file://$USER_HOME$/sdk/go1.22.1" />
+</project>
+`,
+		},
+		{
+			name: "GOPATH and libraries inside GOROOT",
+			workspace: `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="GOPATH" url="file://$USER_HOME$/sdk/go1.21.3/gopath" />
+  <component name="GOROOT" url="file://$USER_HOME$/sdk/go1.21.3" />
+  <component name="GoLibraries">
+    <option name="urls">
+      <list>
+        <option value="file://$USER_HOME$/sdk/go1.21.3/src/vendor" />
+        <option value="file://$USER_HOME$/go/src/other" />
+      </list>
+    </option>
+  </component>
+</project>
+`,
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="GOPATH" url="file://$USER_HOME$/sdk/go1.22.1/gopath" />
+  <component name="GOROOT" url="file://$USER_HOME$/sdk/go1.22.1" />
+  <component name="GoLibraries">
+    <option name="urls">
+      <list>
+        <option value="file://$USER_HOME$/sdk/go1.22.1/src/vendor" />
+        <option value="file://$USER_HOME$/go/src/other" />
+      </list>
+    </option>
+  </component>
+</project>
+`,
+		},
+		{
+			name:      "component in misc.xml",
+			workspace: "",
+			misc: `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="GOROOT" url="file://$USER_HOME$/sdk/go1.21.3" />
+</project>
+`,
+			wantMisc: `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="GOROOT" url="file://$USER_HOME$/sdk/go1.22.1" />
+</project>
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			project := filepath.Join(home, "project")
+			files := map[string]string{"workspace.xml": tt.workspace}
+			if tt.misc != "" {
+				files["misc.xml"] = tt.misc
+			}
+			for name, content := range files {
+				writeTestFile(t, filepath.Join(project, ideaDir, name), content)
+			}
+
+			writes, err := ideaIntegration{homeDir: home}.Apply(project, filepath.Join(home, "sdk", "go1.22.1"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(writes) != 1 {
+				t.Fatalf("writes %+v, one file is expected", writes)
+			}
+			want, wantFile := tt.want, "workspace.xml"
+			if tt.wantMisc != "" {
+				want, wantFile = tt.wantMisc, "misc.xml"
+			}
+			w := writes[0]
+			if w.File != filepath.Join(project, ideaDir, wantFile) {
+				t.Errorf("written %s, want %s", w.File, wantFile)
+			}
+			if got := readTestFile(t, w.File); got != want {
+				t.Errorf("%s:\n%s\nwant:\n%s", wantFile, got, want)
+			}
+			// original content is kept in backup
+			if w.Backup != w.File+backupSuffix || w.Created {
+				t.Fatalf("write %+v, backup is expected", w)
+			}
+			if got := readTestFile(t, w.Backup); got != files[wantFile] {
+				t.Errorf("backup:\n%s\nwant:\n%s", got, files[wantFile])
+			}
+			if got := readTestFile(t, filepath.Join(project, ideaDir, "workspace.xml")); wantFile != "workspace.xml" && got != tt.workspace {
+				t.Errorf("workspace.xml is changed:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestIDEAApplyUpToDate(t *testing.T) {
+	home := t.TempDir()
+	project := filepath.Join(home, "project")
+	workspace := filepath.Join(project, ideaDir, "workspace.xml")
+	writeTestFile(t, workspace, `<project version="4"><component name="GOROOT" url="file://$USER_HOME$/sdk/go1.22.1" /></project>`)
+
+	writes, err := ideaIntegration{homeDir: home}.Apply(project, filepath.Join(home, "sdk", "go1.22.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(writes) != 0 {
+		t.Errorf("writes %+v, nothing is expected", writes)
+	}
+	if _, err := os.Stat(workspace + backupSuffix); !os.IsNotExist(err) {
+		t.Errorf("backup is written: %v", err)
+	}
+}
+
+func writeTestFile(t *testing.T, file string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, file string) string {
+	t.Helper()
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
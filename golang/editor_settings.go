@@ -0,0 +1,163 @@
+package golang
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// settingIntegration patches GOROOT stored as string setting in editor config file.
+type settingIntegration struct {
+	name string
+	// files are glob patterns of config files relative to project directory.
+	files []string
+	// setting matches setting with quoted value (quotes are included into 2nd group).
+	setting *regexp.Regexp
+	// homeDir is used for "~/" expansion.
+	homeDir string
+}
+
+var (
+	jsonGOROOTSetting = regexp.MustCompile(`("GOROOT"\s*:\s*)("(?:[^"\\]|\\.)*")`)
+	envGOROOTSetting  = regexp.MustCompile(`(\bGOROOT\s*=\s*)("(?:[^"\\]|\\.)*")`)
+)
+
+func vscodeIntegration(homeDir string) *settingIntegration {
+	return &settingIntegration{
+		name:    "VS Code",
+		files:   []string{filepath.Join(".vscode", "settings.json")},
+		setting: regexp.MustCompile(`("go\.goroot"\s*:\s*)("(?:[^"\\]|\\.)*")`),
+		homeDir: homeDir,
+	}
+}
+
+func zedIntegration(homeDir string) *settingIntegration {
+	return &settingIntegration{
+		name:    "Zed",
+		files:   []string{filepath.Join(".zed", "settings.json")},
+		setting: jsonGOROOTSetting,
+		homeDir: homeDir,
+	}
+}
+
+func sublimeIntegration(homeDir string) *settingIntegration {
+	return &settingIntegration{
+		name:    "Sublime LSP",
+		files:   []string{"*.sublime-project", "LSP.sublime-settings"},
+		setting: jsonGOROOTSetting,
+		homeDir: homeDir,
+	}
+}
+
+func helixIntegration(homeDir string) *settingIntegration {
+	return &settingIntegration{
+		name:    "Helix",
+		files:   []string{filepath.Join(".helix", "languages.toml")},
+		setting: envGOROOTSetting,
+		homeDir: homeDir,
+	}
+}
+
+func neovimIntegration(homeDir string) *settingIntegration {
+	return &settingIntegration{
+		name:    "Neovim",
+		files:   []string{".nvim.lua", ".neoconf.json"},
+		setting: regexp.MustCompile(jsonGOROOTSetting.String() + `|` + envGOROOTSetting.String()),
+		homeDir: homeDir,
+	}
+}
+
+func (s *settingIntegration) Name() string {
+	return s.name
+}
+
+// configFile returns first existing config file and its content.
+func (s *settingIntegration) configFile(projectDir string) (string, []byte) {
+	for _, pattern := range s.files {
+		names, err := filepath.Glob(filepath.Join(projectDir, pattern))
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			b, err := os.ReadFile(name)
+			if err != nil {
+				continue
+			}
+			return name, b
+		}
+	}
+	return "", nil
+}
+
+// match returns indexes of the setting value (with quotes) in b.
+func (s *settingIntegration) match(b []byte) (int, int, bool) {
+	m := s.setting.FindSubmatchIndex(b)
+	if m == nil {
+		return 0, 0, false
+	}
+	// pattern can be alternation of two patterns with two groups each
+	for i := 4; i+1 < len(m); i += 4 {
+		if m[i] >= 0 {
+			return m[i], m[i+1], true
+		}
+	}
+	return 0, 0, false
+}
+
+// Detect reports whether config file contains GOROOT setting
+// (editor uses GOROOT from environment otherwise, so it's not patched).
+func (s *settingIntegration) Detect(projectDir string) bool {
+	file, b := s.configFile(projectDir)
+	if file == "" {
+		return false
+	}
+	_, _, found := s.match(b)
+	return found
+}
+
+func (s *settingIntegration) CurrentGOROOT(projectDir string) (string, error) {
+	_, b := s.configFile(projectDir)
+	start, end, found := s.match(b)
+	if !found {
+		return "", nil
+	}
+
+	var value string
+	if err := json.Unmarshal(b[start:end], &value); err != nil {
+		return "", err
+	}
+	return expandTilde(value, s.homeDir), nil
+}
+
+func (s *settingIntegration) Apply(projectDir string, goRoot string) ([]FileWrite, error) {
+	file, b := s.configFile(projectDir)
+	if file == "" {
+		return nil, nil
+	}
+
+	value := quoteSetting(goRoot)
+	start, end, found := s.match(b)
+	if !found || string(b[start:end]) == value {
+		return nil, nil
+	}
+	b = append(b[:start:start], append([]byte(value), b[end:]...)...)
+	backup, err := writeFileWithBackup(file, b)
+	if err != nil {
+		return nil, err
+	}
+	return []FileWrite{{File: file, Backup: backup}}, nil
+}
+
+func quoteSetting(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func expandTilde(path string, homeDir string) string {
+	if homeDir == "" || !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	return filepath.Join(homeDir, path[2:])
+}
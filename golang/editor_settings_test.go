@@ -0,0 +1,82 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSettingIntegrationDetect(t *testing.T) {
+	tests := []struct {
+		name   string
+		editor *settingIntegration
+		file   string
+		config string
+		want   bool
+	}{
+		{"VS Code without GOROOT", vscodeIntegration(""), ".vscode/settings.json", `{"editor.tabSize": 4}`, false},
+		{"VS Code", vscodeIntegration(""), ".vscode/settings.json", `{"go.goroot": "/opt/go"}`, true},
+		{"Zed without GOROOT", zedIntegration(""), ".zed/settings.json", `{"theme": "One Dark"}`, false},
+		{"Helix", helixIntegration(""), ".helix/languages.toml", "[language-server.gopls.environment]\nGOROOT = \"/opt/go\"\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := t.TempDir()
+			writeTestFile(t, filepath.Join(project, filepath.FromSlash(tt.file)), tt.config)
+			if got := tt.editor.Detect(project); got != tt.want {
+				t.Errorf("Detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if vscodeIntegration("").Detect(t.TempDir()) {
+		t.Error("VS Code is detected without settings")
+	}
+}
+
+func TestSettingIntegrationApply(t *testing.T) {
+	home := t.TempDir()
+	project := filepath.Join(home, "project")
+	file := filepath.Join(project, ".vscode", "settings.json")
+	orig := "{\n  // team settings\n  \"go.goroot\": \"~/sdk/go1.21.3\",\n  \"go.lintTool\": \"golangci-lint\"\n}\n"
+	writeTestFile(t, file, orig)
+	if err := os.Chmod(file, 0600); err != nil {
+		t.Fatal(err)
+	}
+	e := vscodeIntegration(home)
+
+	current, err := e.CurrentGOROOT(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(home, "sdk", "go1.21.3"); current != want {
+		t.Errorf("CurrentGOROOT() = %s, want %s", current, want)
+	}
+
+	writes, err := e.Apply(project, "/opt/go1.22.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(writes) != 1 || writes[0].File != file || writes[0].Backup != file+backupSuffix {
+		t.Fatalf("writes %+v, write with backup is expected", writes)
+	}
+	want := "{\n  // team settings\n  \"go.goroot\": \"/opt/go1.22.1\",\n  \"go.lintTool\": \"golangci-lint\"\n}\n"
+	if got := readTestFile(t, file); got != want {
+		t.Errorf("settings.json:\n%s\nwant:\n%s", got, want)
+	}
+	if got := readTestFile(t, writes[0].Backup); got != orig {
+		t.Errorf("backup:\n%s\nwant:\n%s", got, orig)
+	}
+	fi, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := fi.Mode().Perm(); mode != 0600 {
+		t.Errorf("settings.json mode is %v, want %v", mode, os.FileMode(0600))
+	}
+
+	// up to date settings are not written
+	writes, err = e.Apply(project, "/opt/go1.22.1")
+	if err != nil || len(writes) != 0 {
+		t.Errorf("writes %+v, %v, nothing is expected", writes, err)
+	}
+}
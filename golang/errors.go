@@ -0,0 +1,81 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNotDownloaded is returned if golang.org/dl helper tool is installed, but its SDK isn't downloaded.
+	ErrNotDownloaded = errors.New("not downloaded")
+	// ErrUpgradeAvailable is returned by Upgrade in check mode if newer version is available.
+	ErrUpgradeAvailable = errors.New("upgrade is available")
+)
+
+// CommandError is returned if external command fails.
+type CommandError struct {
+	Command string
+	Err     error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Command, e.Err)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// VersionNotFoundError is returned if Go version matching spec isn't found.
+type VersionNotFoundError struct {
+	Spec string
+}
+
+func (e *VersionNotFoundError) Error() string {
+	return fmt.Sprintf("Go version matching %q is not found", e.Spec)
+}
+
+// NotSymlinkError is returned if Go binary path exists, but it isn't symlink.
+type NotSymlinkError struct {
+	Path string
+}
+
+func (e *NotSymlinkError) Error() string {
+	return fmt.Sprintf("%s is not symlink", e.Path)
+}
+
+// ProblemsError is returned by Check and Doctor if problems are found.
+type ProblemsError struct {
+	Problems []string
+}
+
+func (e *ProblemsError) Error() string {
+	return fmt.Sprintf("%d problem(s) found", len(e.Problems))
+}
+
+// InstallError is returned by InstallAll if some installs are failed.
+type InstallError struct {
+	Failed []InstallResult
+	Total  int
+}
+
+func (e *InstallError) Error() string {
+	versions := make([]string, 0, len(e.Failed))
+	for _, r := range e.Failed {
+		versions = append(versions, r.Version)
+	}
+	return fmt.Sprintf("%d of %d installs failed: %s", len(e.Failed), e.Total, strings.Join(versions, ", "))
+}
+
+// PolicyError is returned if Go version violates enforced team policy.
+type PolicyError struct {
+	Version string
+	// Source is path or URL of policy file.
+	Source     string
+	Violations []string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("Go %s is not allowed by policy %s: %s", e.Version, e.Source, strings.Join(e.Violations, "; "))
+}
@@ -0,0 +1,124 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// FetchOpts controls Fetch behaviour.
+type FetchOpts struct {
+	// OS and Arch are target platform of SDK, current platform by default.
+	OS   string
+	Arch string
+	// Dest is directory to unpack SDK to (it becomes GOROOT),
+	// go<version>.<os>-<arch> in current directory by default.
+	Dest string
+	// Force replaces Dest if it contains SDK unpacked by golangver before
+	// (other non-empty directories are never removed).
+	Force bool
+	// Offline fetches from cached archives or FromDir only (without network access).
+	Offline bool
+	// FromDir is directory with Go release archives (go<version>.<os>-<arch>.tar.gz).
+	FromDir string
+	// IgnorePolicy allows to fetch versions violating team policy (see Policy).
+	IgnorePolicy bool
+}
+
+// FetchResult is result of Fetch.
+type FetchResult struct {
+	Version string
+	// Platform is target platform of SDK ("linux/arm64").
+	Platform string
+	// Archive is file name of release archive.
+	Archive string
+	// Dest is directory SDK is unpacked to.
+	Dest     string
+	Duration time.Duration
+}
+
+// Fetch downloads release archive of Go version for any platform (archives are cached like by Install)
+// and unpacks it to opts.Dest. Unlike Install it doesn't register SDK as local version,
+// so it can be used to put Go distribution to container image built for other platform.
+func (m *Manager) Fetch(version string, opts FetchOpts) (*FetchResult, error) {
+	start := time.Now()
+	goos, goarch := opts.OS, opts.Arch
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+	platform := goos + "/" + goarch
+	archiveName := archiveFilename(version, goos, goarch)
+	dest := opts.Dest
+	if dest == "" {
+		dest = fmt.Sprintf("go%s.%s-%s", version, goos, goarch)
+	}
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.IgnorePolicy {
+		if err := m.checkPolicy(version); err != nil {
+			return nil, err
+		}
+	}
+	empty, err := isEmptyDir(dest)
+	if err != nil {
+		return nil, err
+	}
+	if !empty {
+		if !isUnpackedSDK(dest) {
+			return nil, fmt.Errorf("%s is not empty and doesn't contain Go SDK unpacked by golangver, it won't be replaced", dest)
+		}
+		if !opts.Force {
+			return nil, fmt.Errorf("%s contains Go SDK (use --force to replace it)", dest)
+		}
+	}
+
+	fmt.Fprintf(m.Stdout, "Fetch Go %s for %s...\n", version, platform)
+	archive, err := m.fetchArchive(m.Stdout, version, goos, goarch, InstallOpts{Offline: opts.Offline, FromDir: opts.FromDir})
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verifyLockedArchive(version, platform, archiveName, archive); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(m.Stdout, "Unpack %s to %s...\n", archive, dest)
+	err = unpackTo(archive, dest, func(dir string) error {
+		unpacked, err := goRootVersion(dir)
+		if err != nil {
+			return fmt.Errorf("version detection is failed: %w", err)
+		}
+		if unpacked != version {
+			return fmt.Errorf("%s contains Go %s", archiveName, unpacked)
+		}
+		// marker allows to replace dest by the next fetch
+		return os.WriteFile(filepath.Join(dir, unpackedMarker), nil, 0644)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FetchResult{Version: version, Platform: platform, Archive: archiveName, Dest: dest, Duration: time.Since(start)}, nil
+}
+
+// isUnpackedSDK reports whether dir contains Go SDK unpacked by golangver (or golang.org/dl helper tool).
+func isUnpackedSDK(dir string) bool {
+	return fileExists(filepath.Join(dir, unpackedMarker)) && fileExists(filepath.Join(dir, "VERSION"))
+}
+
+// isEmptyDir reports whether dir doesn't exist or is empty directory.
+func isEmptyDir(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
@@ -0,0 +1,61 @@
+package golang_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+func TestFetchForceKeepsProject(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	if err := env.WriteProjectFile("go.mod", "module example.com/p\n\ngo 1.22\n"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := m.Fetch("1.22.1", golang.FetchOpts{Dest: env.ProjectDir, Force: true})
+	if err == nil {
+		t.Fatal("Fetch to project directory error is expected")
+	}
+	requireContains(t, err.Error(), "won't be replaced")
+	if got, err := env.ReadProjectFile("go.mod"); err != nil || !strings.Contains(got, "module example.com/p") {
+		t.Fatalf("go.mod = %q, %v after refused fetch", got, err)
+	}
+}
+
+func TestFetchForceReplacesFetchedSDK(t *testing.T) {
+	env, m := newTestEnv(t, "1.21.3", "1.22.1")
+	dest := filepath.Join(env.HomeDir, "sdk")
+	if _, err := m.Fetch("1.21.3", golang.FetchOpts{Dest: dest}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Fetch("1.22.1", golang.FetchOpts{Dest: dest}); err == nil {
+		t.Fatal("Fetch without --force to fetched SDK error is expected")
+	}
+	if _, err := m.Fetch("1.22.1", golang.FetchOpts{Dest: dest, Force: true}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(dest, "VERSION"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "go1.22.1") {
+		t.Errorf("VERSION = %q, go1.22.1 is expected", b)
+	}
+}
+
+func TestFetchDestIsReadableByEveryone(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	dest := filepath.Join(env.HomeDir, "image", "go")
+	if _, err := m.Fetch("1.22.1", golang.FetchOpts{Dest: dest}); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := fi.Mode().Perm(); mode != 0755 {
+		t.Errorf("%s mode is %v, want %v", dest, mode, os.FileMode(0755))
+	}
+}
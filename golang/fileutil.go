@@ -0,0 +1,65 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const backupSuffix = ".golangver.bak"
+
+// writeFileAtomic writes data to the temporary file and renames it to file,
+// so readers never see partially written file. Permissions of existing file are kept.
+func writeFileAtomic(file string, data []byte) error {
+	perm := os.FileMode(0644)
+	if fi, err := os.Stat(file); err == nil {
+		perm = fi.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// writeFileWithBackup copies existing file to backup file and writes data atomically.
+// Returns backup file path (empty if file hasn't existed).
+func writeFileWithBackup(file string, data []byte) (string, error) {
+	var backup string
+	orig, err := os.ReadFile(file)
+	switch {
+	case err == nil:
+		backup = file + backupSuffix
+		if err := writeFileAtomic(backup, orig); err != nil {
+			return "", err
+		}
+	case !os.IsNotExist(err):
+		return "", err
+	}
+	return backup, writeFileAtomic(file, data)
+}
+
+// FileWrite describes file written by golangver.
+type FileWrite struct {
+	File string
+	// Backup is path of previous file content copy (empty if file is created or backup isn't made).
+	Backup string
+	// Created is set if file hasn't existed.
+	Created bool
+}
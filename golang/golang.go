@@ -1,31 +1,52 @@
 // Package golang provides tools for Go version management on localhost.
+//
+// All operations are methods of Manager, which writes progress output to injectable writers,
+// asks confirmations through Prompter and runs external commands through Runner,
+// so package can be embedded into other tools.
 package golang
 
 import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"go/build"
+	"io"
 	"io/fs"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
-func runCmd(bin string, args ...string) error {
-	cmd := exec.Command(bin, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s %s: %w", bin, strings.Join(args, " "), err)
+// run runs command in project directory with output redirected to out (see command).
+func (m *Manager) run(out io.Writer, bin string, args ...string) error {
+	return m.runCommand(m.command(out, bin, args...))
+}
+
+// command returns command run in project directory with stdout redirected to out
+// (stderr is redirected to out too if out is not Stdout).
+func (m *Manager) command(out io.Writer, bin string, args ...string) *Command {
+	stderr := out
+	if out == m.Stdout {
+		stderr = m.Stderr
+	}
+	return &Command{Name: bin, Args: args, Dir: m.ProjectDir, Stdout: out, Stderr: stderr}
+}
+
+// runCommand runs command with Runner, its failure is returned as *CommandError.
+func (m *Manager) runCommand(cmd *Command) error {
+	m.Logger.Debug("run command", "bin", cmd.Name, "args", cmd.Args, "dir", cmd.Dir)
+	start := time.Now()
+	if err := m.Runner.Run(cmd); err != nil {
+		m.Logger.Debug("command failed", "bin", cmd.Name, "duration", time.Since(start), "err", err)
+		return &CommandError{Command: cmd.String(), Err: err}
 	}
+	m.Logger.Debug("command finished", "bin", cmd.Name, "duration", time.Since(start))
 	return nil
 }
 
 // BinaryPath returns path to go binary for provided version.
-func BinaryPath(version string) (string, error) {
-	goRoot, err := golangBinGOROOT("go" + version)
+func (m *Manager) BinaryPath(version string) (string, error) {
+	goRoot, err := m.binGOROOT("go" + version)
 	if err != nil {
 		return "", err
 	}
@@ -33,7 +54,7 @@ func BinaryPath(version string) (string, error) {
 }
 
 // goBinCheckSymlink cheks is provided path symlink if exists
-func goBinCheckSymlink(fpath string) (string, error) {
+func (m *Manager) goBinCheckSymlink(fpath string) (string, error) {
 	fileInfo, err := os.Lstat(fpath)
 	if err != nil {
 		if _, ok := err.(*fs.PathError); !ok {
@@ -43,28 +64,26 @@ func goBinCheckSymlink(fpath string) (string, error) {
 	}
 
 	if fileInfo.Mode()&os.ModeSymlink != os.ModeSymlink {
-		return "", fmt.Errorf("%s is not symlink", fpath)
+		return "", &NotSymlinkError{Path: fpath}
 	}
 
 	originFile, err := os.Readlink(fpath)
 	if err != nil {
 		return "", err
 	}
+	m.Logger.Debug("symlink is found", "link", fpath, "target", originFile)
 	return originFile, nil
 }
 
-var ErrNotDownloaded = fmt.Errorf("not downloaded")
-
-func golangBinGOROOT(bin string) (string, error) {
+// binGOROOT returns GOROOT reported by go binary (bin is looked up in PATH).
+func (m *Manager) binGOROOT(bin string) (string, error) {
 	var (
 		stdout bytes.Buffer
 		stderr bytes.Buffer
 	)
-	cmd := exec.Command(bin, "env", "-json", "GOROOT")
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	// cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	err := m.runCommand(&Command{Name: bin, Args: []string{"env", "-json", "GOROOT"}, Stdout: &stdout, Stderr: &stderr})
+	if err != nil {
+		m.Logger.Debug("GOROOT detection is failed", "bin", bin, "stderr", stderr.String())
 		if strings.Contains(stderr.String(), "not downloaded.") {
 			return "", ErrNotDownloaded
 		}
@@ -78,21 +97,45 @@ func golangBinGOROOT(bin string) (string, error) {
 	if err := json.NewDecoder(&stdout).Decode(&goe); err != nil {
 		return "", err
 	}
+	m.Logger.Debug("GOROOT is detected", "bin", bin, "goroot", goe.GOROOT)
 	return goe.GOROOT, nil
 }
 
-func parseGolangBin(bin string) (string, error) {
-	goRoot, err := golangBinGOROOT(bin)
+func (m *Manager) parseGolangBin(bin string) (string, error) {
+	goRoot, err := m.binGOROOT(bin)
 	if err != nil {
 		return "", err
 	}
 	return goBin(goRoot), nil
 }
 
-func goBin(goRoot string) string {
-	return filepath.Join(goRoot, "bin", "go")
+// goRootVersion returns Go version of SDK (without "go" prefix) from its VERSION file.
+func goRootVersion(goRoot string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(goRoot, "VERSION"))
+	if err != nil {
+		return "", err
+	}
+	line := strings.SplitN(string(b), "\n", 2)[0]
+	return strings.TrimPrefix(strings.TrimSpace(line), "go"), nil
+}
+
+// currentVersion returns GOROOT and Go version of linkPath symlink target.
+func (m *Manager) currentVersion(linkPath string) (string, string, error) {
+	target, err := m.goBinCheckSymlink(linkPath)
+	if err != nil {
+		return "", "", fmt.Errorf("check symlink %s is failed: %w", linkPath, err)
+	}
+	if target == "" {
+		return "", "", fmt.Errorf("symlink %s not found", linkPath)
+	}
+	goRoot := filepath.Dir(filepath.Dir(target))
+	version, err := goRootVersion(goRoot)
+	if err != nil {
+		return "", "", fmt.Errorf("version of %s detection is failed: %w", goRoot, err)
+	}
+	return goRoot, version, nil
 }
 
-func golangBinDir() string {
-	return filepath.Join(build.Default.GOPATH, "bin")
+func goBin(goRoot string) string {
+	return filepath.Join(goRoot, "bin", "go")
 }
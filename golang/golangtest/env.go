@@ -0,0 +1,267 @@
+// Package golangtest provides fake Go toolchain fixtures for golang.Manager:
+// temporary HOME/GOPATH with fake SDKs and golang.org/dl helper tools,
+// command runner emulating go commands and releases server, so Manager (and CLI on top of it)
+// can be exercised end to end without network and real Go installations.
+//
+// Fake executables are POSIX shell scripts.
+package golangtest
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+// unpackedMarker is created in SDK directory after successful unpack (like golang.org/dl does).
+const unpackedMarker = ".unpacked-success"
+
+// Env is fake user environment in temporary directory:
+//
+//	home/            HOME
+//	home/go/         GOPATH, SDKs downloaded by IDEA (go1.X.Y)
+//	home/go/bin/     golang.org/dl helper tools (go1.X.Y)
+//	home/sdk/        SDKs downloaded by helper tools
+//	cache/           golangver cache
+//	project/         project directory
+//	bin/go           Go binary symlink (golangver --go-bin)
+type Env struct {
+	Dir        string
+	HomeDir    string
+	GOPATH     string
+	SDKDir     string
+	CacheDir   string
+	ProjectDir string
+	// GoBinLink is path of Go binary symlink managed by golangver (it's not created by NewEnv).
+	GoBinLink string
+}
+
+// NewEnv creates fake environment in dir (it should be empty, e.g. testing.T.TempDir()).
+func NewEnv(dir string) (*Env, error) {
+	home := filepath.Join(dir, "home")
+	e := &Env{
+		Dir:        dir,
+		HomeDir:    home,
+		GOPATH:     filepath.Join(home, "go"),
+		SDKDir:     filepath.Join(home, "sdk"),
+		CacheDir:   filepath.Join(dir, "cache"),
+		ProjectDir: filepath.Join(dir, "project"),
+		GoBinLink:  filepath.Join(dir, "bin", "go"),
+	}
+	for _, d := range []string{e.BinDir(), e.SDKDir, e.CacheDir, e.ProjectDir, filepath.Dir(e.GoBinLink)} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// BinDir returns directory of golang.org/dl helper tools.
+func (e *Env) BinDir() string {
+	return filepath.Join(e.GOPATH, "bin")
+}
+
+// SDKPath returns SDK directory of version downloaded by helper tool.
+func (e *Env) SDKPath(version string) string {
+	return filepath.Join(e.SDKDir, "go"+version)
+}
+
+// WrapperPath returns path of golang.org/dl helper tool of version.
+func (e *Env) WrapperPath(version string) string {
+	return filepath.Join(e.BinDir(), "go"+version)
+}
+
+// Manager returns non-interactive Manager working in the environment with FakeRunner.
+// Output is discarded and HTTP requests fail until releases server is configured (see ReleaseServer.Configure).
+func (e *Env) Manager() *golang.Manager {
+	return &golang.Manager{
+		Stdout:          io.Discard,
+		Stderr:          io.Discard,
+		Prompter:        golang.DefaultAnswers{},
+		Runner:          NewFakeRunner(e),
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		HomeDir:         e.HomeDir,
+		GOPATH:          e.GOPATH,
+		SDKDir:          e.SDKDir,
+		CacheDir:        e.CacheDir,
+		ProjectDir:      e.ProjectDir,
+		HTTPClient:      &http.Client{Transport: offlineTransport{}},
+		ReleaseIndexURL: "http://golangtest.invalid/?mode=json&include=all",
+		DownloadBaseURL: "http://golangtest.invalid/",
+		Editors:         golang.DefaultEditors(e.HomeDir),
+	}
+}
+
+// offlineTransport fails all requests.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("network is not available in fake environment: %s", req.URL)
+}
+
+// Environ returns environment variables for fake executables and golangver binary run in the environment.
+func (e *Env) Environ() []string {
+	return append(os.Environ(),
+		"HOME="+e.HomeDir,
+		"GOPATH="+e.GOPATH,
+		"XDG_CACHE_HOME="+e.CacheDir,
+		"XDG_CONFIG_HOME="+filepath.Join(e.Dir, "config"),
+		"PATH="+e.BinDir()+string(os.PathListSeparator)+os.Getenv("PATH"),
+	)
+}
+
+// AddSDK creates fake SDK of version downloaded by helper tool and the helper tool itself.
+func (e *Env) AddSDK(version string) error {
+	if err := WriteSDK(e.SDKPath(version), version); err != nil {
+		return err
+	}
+	return e.AddWrapper(version)
+}
+
+// AddIDEASDK creates fake SDK of version downloaded by IDEA (~/go/go<version>).
+func (e *Env) AddIDEASDK(version string) error {
+	return WriteSDK(filepath.Join(e.GOPATH, "go"+version), version)
+}
+
+// AddWrapper creates golang.org/dl helper tool of version, its SDK isn't downloaded until AddSDK is called.
+func (e *Env) AddWrapper(version string) error {
+	script := fmt.Sprintf(`#!/bin/sh
+sdk=%q
+if [ ! -e "$sdk/%s" ]; then
+	echo "go%s: not downloaded. Run 'go%s download' to install to $sdk" >&2
+	exit 1
+fi
+exec "$sdk/bin/go" "$@"
+`, e.SDKPath(version), unpackedMarker, version, version)
+	return writeExecutable(e.WrapperPath(version), script)
+}
+
+// Use points Go binary symlink at SDK of version.
+func (e *Env) Use(version string) error {
+	os.Remove(e.GoBinLink)
+	return os.Symlink(filepath.Join(e.SDKPath(version), "bin", "go"), e.GoBinLink)
+}
+
+// WriteProjectFile writes file relative to project directory.
+func (e *Env) WriteProjectFile(name string, content string) error {
+	file := filepath.Join(e.ProjectDir, name)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(content), 0644)
+}
+
+// ReadProjectFile reads file relative to project directory.
+func (e *Env) ReadProjectFile(name string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(e.ProjectDir, name))
+	return string(b), err
+}
+
+// WriteSDK creates fake SDK tree of version in dir: VERSION file, bin/go executable and unpack marker.
+// Fake go binary supports `go version`, `go env [-json] [vars...]` and `go env -w|-u`
+// (configuration is stored in $XDG_CONFIG_HOME/go/env like real go does),
+// GOROOT is detected by location of the binary (symlinks are followed).
+func WriteSDK(dir string, version string) error {
+	for name, f := range sdkFiles(version) {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, []byte(f.content), f.mode); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filepath.Join(dir, unpackedMarker), nil, 0644)
+}
+
+type sdkFile struct {
+	content string
+	mode    os.FileMode
+}
+
+// sdkFiles returns files of fake SDK (paths are relative to SDK root).
+func sdkFiles(version string) map[string]sdkFile {
+	goScript := fmt.Sprintf(`#!/bin/sh
+self="$0"
+while [ -L "$self" ]; do
+	target=$(readlink "$self")
+	case "$target" in
+	/*) self="$target" ;;
+	*) self="$(dirname "$self")/$target" ;;
+	esac
+done
+GOROOT=$(cd "$(dirname "$self")/.." && pwd)
+GOENV="${XDG_CONFIG_HOME:-$HOME/.config}/go/env"
+
+value() {
+	case "$1" in
+	GOROOT) echo "$GOROOT" ;;
+	GOVERSION) echo "go%[1]s" ;;
+	*)
+		v=$(eval "printf '%%s' \"\${$1:-}\"")
+		if [ -z "$v" ] && [ -f "$GOENV" ]; then
+			v=$(sed -n "s/^$1=//p" "$GOENV")
+		fi
+		echo "$v" ;;
+	esac
+}
+
+unsetenv() {
+	if [ -f "$GOENV" ]; then
+		grep -v "^$1=" "$GOENV" > "$GOENV.tmp"
+		mv "$GOENV.tmp" "$GOENV"
+	fi
+}
+
+case "$1" in
+version)
+	echo "go version go%[1]s %[2]s/%[3]s" ;;
+env)
+	shift
+	case "$1" in
+	-w)
+		shift
+		mkdir -p "$(dirname "$GOENV")"
+		for kv in "$@"; do
+			unsetenv "${kv%%%%=*}"
+			echo "$kv" >> "$GOENV"
+		done ;;
+	-u)
+		shift
+		for k in "$@"; do unsetenv "$k"; done ;;
+	-json)
+		shift
+		[ $# -eq 0 ] && set -- GOROOT GOVERSION
+		printf '{'
+		sep=''
+		for k in "$@"; do
+			printf '%%s\n\t"%%s": "%%s"' "$sep" "$k" "$(value "$k")"
+			sep=','
+		done
+		printf '\n}\n' ;;
+	*)
+		for k in "$@"; do value "$k"; done ;;
+	esac ;;
+*)
+	echo "fake go%[1]s: unsupported command: $*" >&2
+	exit 2 ;;
+esac
+`, version, runtime.GOOS, runtime.GOARCH)
+	return map[string]sdkFile{
+		"VERSION":                      {content: "go" + version + "\ntime 2023-01-01T00:00:00Z\n", mode: 0644},
+		filepath.Join("bin", "go"):     {content: goScript, mode: 0755},
+		filepath.Join("src", "go.mod"): {content: "module std\n", mode: 0644},
+	}
+}
+
+func writeExecutable(file string, script string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(script), 0755)
+}
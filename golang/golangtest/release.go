@@ -0,0 +1,269 @@
+package golangtest
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+// Release is Go release served by ReleaseServer.
+type Release struct {
+	Version string
+	// Unstable marks pre-release (beta, rc).
+	Unstable bool
+	// Platforms are additional platforms ("linux/arm64") with archives in index.
+	Platforms []string
+}
+
+// ReleaseServer is local HTTP server of Go releases index and archives of fake SDKs
+// (archives are built for host platform and Release.Platforms, Windows archives are zip files).
+type ReleaseServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	releases []Release
+	archives map[string][]byte
+	requests []string
+}
+
+type indexFile struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	Sha256   string `json:"sha256"`
+	Size     int    `json:"size"`
+	Kind     string `json:"kind"`
+}
+
+type indexRelease struct {
+	Version string      `json:"version"`
+	Stable  bool        `json:"stable"`
+	Files   []indexFile `json:"files"`
+}
+
+// NewReleaseServer starts server of stable releases of versions.
+func NewReleaseServer(versions ...string) (*ReleaseServer, error) {
+	s := &ReleaseServer{archives: map[string][]byte{}}
+	for _, v := range versions {
+		if err := s.AddRelease(Release{Version: v}); err != nil {
+			return nil, err
+		}
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s, nil
+}
+
+// AddRelease adds release with archives of fake SDK for host platform and r.Platforms.
+func (s *ReleaseServer) AddRelease(r Release) error {
+	archives := map[string][]byte{}
+	for _, p := range releasePlatforms(r) {
+		archive, err := Archive(r.Version, p[0])
+		if err != nil {
+			return err
+		}
+		archives[archiveFilename(r.Version, p[0], p[1])] = archive
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releases = append(s.releases, r)
+	for name, archive := range archives {
+		s.archives[name] = archive
+	}
+	return nil
+}
+
+// releasePlatforms returns OS and architecture pairs of release archives (host platform is first).
+func releasePlatforms(r Release) [][2]string {
+	platforms := [][2]string{{runtime.GOOS, runtime.GOARCH}}
+	for _, p := range r.Platforms {
+		parts := strings.SplitN(p, "/", 2)
+		if len(parts) == 2 && (parts[0] != runtime.GOOS || parts[1] != runtime.GOARCH) {
+			platforms = append(platforms, [2]string{parts[0], parts[1]})
+		}
+	}
+	return platforms
+}
+
+// Requests returns paths of served requests.
+func (s *ReleaseServer) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// Configure points releases index and downloads of m at the server.
+func (s *ReleaseServer) Configure(m *golang.Manager) {
+	m.HTTPClient = s.Client()
+	m.ReleaseIndexURL = s.URL + "/?mode=json&include=all"
+	m.DownloadBaseURL = s.URL + "/"
+}
+
+func (s *ReleaseServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, req.URL.Path)
+	s.mu.Unlock()
+
+	if req.URL.Path == "/" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.index())
+		return
+	}
+	s.mu.Lock()
+	archive, ok := s.archives[path.Base(req.URL.Path)]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	http.ServeContent(w, req, path.Base(req.URL.Path), serverModTime, bytes.NewReader(archive))
+}
+
+// index returns releases index (newest first, like go.dev does).
+func (s *ReleaseServer) index() []indexRelease {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var index []indexRelease
+	for _, r := range s.releases {
+		ir := indexRelease{Version: "go" + r.Version, Stable: !r.Unstable}
+		for _, p := range releasePlatforms(r) {
+			name := archiveFilename(r.Version, p[0], p[1])
+			sum := sha256.Sum256(s.archives[name])
+			ir.Files = append(ir.Files, indexFile{
+				Filename: name,
+				OS:       p[0],
+				Arch:     p[1],
+				Version:  "go" + r.Version,
+				Sha256:   hex.EncodeToString(sum[:]),
+				Size:     len(s.archives[name]),
+				Kind:     "archive",
+			})
+		}
+		index = append(index, ir)
+	}
+	sort.SliceStable(index, func(i, j int) bool {
+		return versionLess(index[j].Version, index[i].Version)
+	})
+	return index
+}
+
+// Archive returns release archive of fake SDK (tar.gz or zip for windows) with go/ prefix.
+func Archive(version string, goos string) ([]byte, error) {
+	files := sdkFiles(version)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	if goos == "windows" {
+		zw := zip.NewWriter(&buf)
+		for _, name := range names {
+			h := &zip.FileHeader{Name: "go/" + filepath.ToSlash(name), Method: zip.Deflate, Modified: serverModTime}
+			h.SetMode(files[name].mode)
+			w, err := zw.CreateHeader(h)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := w.Write([]byte(files[name].content)); err != nil {
+				return nil, err
+			}
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, name := range names {
+		f := files[name]
+		h := &tar.Header{
+			Name:     "go/" + filepath.ToSlash(name),
+			Mode:     int64(f.mode),
+			Size:     int64(len(f.content)),
+			ModTime:  serverModTime,
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(f.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// archiveFilename returns file name of Go release archive for goos/goarch.
+func archiveFilename(version string, goos string, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return "go" + version + "." + goos + "-" + goarch + ext
+}
+
+// serverModTime is modification time of served archives and their files.
+var serverModTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// versionLess compares Go versions ("go1.21.3", "go1.22rc1"), pre-release is older than release.
+func versionLess(a, b string) bool {
+	ap, as := splitVersion(a)
+	bp, bs := splitVersion(b)
+	for i := 0; i < 3; i++ {
+		if ap[i] != bp[i] {
+			return ap[i] < bp[i]
+		}
+	}
+	switch {
+	case as == bs:
+		return false
+	case as == "":
+		return false
+	case bs == "":
+		return true
+	}
+	return as < bs
+}
+
+// splitVersion splits "go1.22rc1" to [1 22 0] and "rc1".
+func splitVersion(v string) ([3]int, string) {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "go")
+	for i, p := range strings.SplitN(v, ".", 3) {
+		n := 0
+		j := 0
+		for ; j < len(p) && p[j] >= '0' && p[j] <= '9'; j++ {
+			n = n*10 + int(p[j]-'0')
+		}
+		parts[i] = n
+		if j < len(p) {
+			return parts, p[j:]
+		}
+	}
+	return parts, ""
+}
@@ -0,0 +1,126 @@
+package golangtest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+// FakeRunner is golang.Runner which emulates network dependent commands of Go toolchain (`go install`,
+// `go mod edit`, `go mod tidy`) and runs fake executables of Env (helper tools, SDK binaries).
+// All commands are recorded.
+type FakeRunner struct {
+	env *Env
+
+	// Fallback runs commands which are neither emulated nor fake executables
+	// (if nil, they fail with exec.ErrNotFound).
+	Fallback golang.Runner
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// NewFakeRunner returns FakeRunner for env.
+func NewFakeRunner(env *Env) *FakeRunner {
+	return &FakeRunner{env: env}
+}
+
+// Calls returns recorded commands ("name arg1 arg2...") in order of calls.
+func (r *FakeRunner) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+var dlToolRe = regexp.MustCompile(`^golang\.org/dl/go(.+)@latest$`)
+
+// Run runs or emulates command.
+func (r *FakeRunner) Run(cmd *golang.Command) error {
+	r.mu.Lock()
+	r.calls = append(r.calls, cmd.String())
+	r.mu.Unlock()
+
+	if cmd.Name == "go" || (filepath.Base(cmd.Name) == "go" && r.fakeExecutable(cmd.Name) != "") {
+		if handled, err := r.emulateGo(cmd); handled {
+			return err
+		}
+	}
+	if bin := r.fakeExecutable(cmd.Name); bin != "" {
+		c := *cmd
+		c.Name = bin
+		if c.Env == nil {
+			c.Env = r.env.Environ()
+		}
+		return golang.ExecRunner{}.Run(&c)
+	}
+	if r.Fallback != nil {
+		return r.Fallback.Run(cmd)
+	}
+	return &exec.Error{Name: cmd.Name, Err: exec.ErrNotFound}
+}
+
+// fakeExecutable returns path of fake executable by command name (empty if it's not found).
+func (r *FakeRunner) fakeExecutable(name string) string {
+	bin := name
+	switch {
+	case name == "go":
+		bin = r.env.GoBinLink
+	case !filepath.IsAbs(name):
+		bin = filepath.Join(r.env.BinDir(), name)
+	}
+	if rel, err := filepath.Rel(r.env.Dir, bin); err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	if _, err := os.Stat(bin); err != nil {
+		return ""
+	}
+	return bin
+}
+
+// emulateGo emulates go commands which require network or real toolchain:
+// `go install` (helper tools are created, other packages are only recorded), `go mod edit -go` and `go mod tidy`.
+func (r *FakeRunner) emulateGo(cmd *golang.Command) (bool, error) {
+	args := cmd.Args
+	stderr := cmd.Stderr
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	switch {
+	case len(args) == 2 && args[0] == "install" && dlToolRe.MatchString(args[1]):
+		version := dlToolRe.FindStringSubmatch(args[1])[1]
+		fmt.Fprintf(stderr, "go: downloading golang.org/dl v0.0.0 (fake go%s)\n", version)
+		return true, r.env.AddWrapper(version)
+	case len(args) == 2 && args[0] == "install" && strings.Contains(args[1], "@"):
+		fmt.Fprintf(stderr, "go: downloading %s (fake)\n", args[1])
+		return true, nil
+	case len(args) == 3 && args[0] == "mod" && args[1] == "edit" && strings.HasPrefix(args[2], "-go="):
+		return true, setGoDirective(filepath.Join(cmd.Dir, "go.mod"), strings.TrimPrefix(args[2], "-go="))
+	case len(args) >= 2 && args[0] == "mod" && args[1] == "tidy":
+		return true, nil
+	}
+	return false, nil
+}
+
+var goDirectiveRe = regexp.MustCompile(`(?m)^go[ \t]+[^\s/]+`)
+
+// setGoDirective sets go directive in go.mod like `go mod edit -go=version` does.
+func setGoDirective(goMod string, version string) error {
+	b, err := os.ReadFile(goMod)
+	if err != nil {
+		return err
+	}
+	directive := "go " + version
+	if goDirectiveRe.Match(b) {
+		b = goDirectiveRe.ReplaceAll(b, []byte(directive))
+	} else {
+		b = append(b, []byte("\n"+directive+"\n")...)
+	}
+	return os.WriteFile(goMod, b, 0644)
+}
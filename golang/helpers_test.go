@@ -0,0 +1,44 @@
+package golang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+// alwaysYes confirms all prompts.
+var alwaysYes = golang.PrompterFunc(func(string, bool) (bool, error) { return true, nil })
+
+// newTestEnv returns fake environment with releases server of versions and Manager configured for it.
+func newTestEnv(t *testing.T, versions ...string) (*golangtest.Env, *golang.Manager) {
+	t.Helper()
+	env, m, _ := newTestServerEnv(t, versions...)
+	return env, m
+}
+
+// newTestServerEnv is newTestEnv which returns releases server too.
+func newTestServerEnv(t *testing.T, versions ...string) (*golangtest.Env, *golang.Manager, *golangtest.ReleaseServer) {
+	t.Helper()
+	env, err := golangtest.NewEnv(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := golangtest.NewReleaseServer(versions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	m := env.Manager()
+	srv.Configure(m)
+	return env, m, srv
+}
+
+// requireContains fails test if s doesn't contain substr.
+func requireContains(t *testing.T, s string, substr string) {
+	t.Helper()
+	if !strings.Contains(s, substr) {
+		t.Fatalf("%q doesn't contain %q", s, substr)
+	}
+}
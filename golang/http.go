@@ -0,0 +1,48 @@
+package golang
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// setMirror points archive downloads at mirror base URL, releases index is still fetched from ReleaseIndexURL
+// because mirrors of https://dl.google.com/go/ don't serve it.
+func (m *Manager) setMirror(mirror string) {
+	m.DownloadBaseURL = strings.TrimSuffix(mirror, "/") + "/"
+}
+
+// newHTTPClient returns HTTP client which uses proxy from environment
+// (HTTPS_PROXY, HTTP_PROXY, NO_PROXY) and trusts CA certificates from caBundle in addition to system ones.
+func newHTTPClient(caBundle string, logger *slog.Logger) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxy, err := http.ProxyFromEnvironment(req)
+		if proxy != nil {
+			logger.Debug("http proxy", "url", req.URL.String(), "proxy", proxy.Redacted())
+		}
+		return proxy, err
+	}
+
+	if caBundle != "" {
+		pem, err := os.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("CA bundle read is failed: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA certificates are not found in %s", caBundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		logger.Debug("CA bundle is loaded", "file", caBundle)
+	}
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,130 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/pem"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyConfigMirror(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		wantIndex string
+		wantBase  string
+	}{
+		{"default", Config{}, defaultReleaseIndexURL, defaultDownloadBaseURL},
+		{"mirror", Config{Mirror: "https://artifactory.example.com/go-dl"}, defaultReleaseIndexURL, "https://artifactory.example.com/go-dl/"},
+		{
+			"mirror and index",
+			Config{Mirror: "https://artifactory.example.com/go-dl/", IndexURL: "https://artifactory.example.com/go-dev/dl/?mode=json&include=all"},
+			"https://artifactory.example.com/go-dev/dl/?mode=json&include=all", "https://artifactory.example.com/go-dl/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewManager()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := m.ApplyConfig(&tt.cfg); err != nil {
+				t.Fatal(err)
+			}
+			if m.ReleaseIndexURL != tt.wantIndex || m.DownloadBaseURL != tt.wantBase {
+				t.Errorf("index %s, base %s, want %s, %s", m.ReleaseIndexURL, m.DownloadBaseURL, tt.wantIndex, tt.wantBase)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dir := t.TempDir()
+
+	client, err := newHTTPClient("", logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Fatal("certificate of test server isn't expected to be trusted without CA bundle")
+	}
+
+	bundle := filepath.Join(dir, "ca.pem")
+	writeTestFile(t, bundle, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})))
+	client, err = newHTTPClient(bundle, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	empty := filepath.Join(dir, "empty.pem")
+	writeTestFile(t, empty, "no certificates\n")
+	if _, err := newHTTPClient(empty, logger); err == nil || !strings.Contains(err.Error(), "CA certificates are not found") {
+		t.Errorf("error = %v, missing certificates error is expected", err)
+	}
+	if _, err := newHTTPClient(filepath.Join(dir, "missing.pem"), logger); err == nil {
+		t.Error("error is expected for missing CA bundle")
+	}
+}
+
+// TestNewHTTPClientProxy is run in subprocess because proxy environment is read by net/http once per process.
+func TestNewHTTPClientProxy(t *testing.T) {
+	if os.Getenv("GOLANGVER_TEST_PROXY") == "" {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestNewHTTPClientProxy$", "-test.v")
+		cmd.Env = append(os.Environ(),
+			"GOLANGVER_TEST_PROXY=1",
+			"HTTPS_PROXY=http://proxy.example.com:3128", "HTTP_PROXY=", "NO_PROXY=internal.example.com",
+			"https_proxy=", "http_proxy=", "no_proxy=")
+		out, err := cmd.CombinedOutput()
+		if err != nil || !strings.Contains(string(out), "--- PASS: TestNewHTTPClientProxy") {
+			t.Fatalf("subprocess: %v\n%s", err, out)
+		}
+		return
+	}
+
+	var logs bytes.Buffer
+	client, err := newHTTPClient("", slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := client.Transport.(*http.Transport).Proxy
+	for url, want := range map[string]string{
+		"https://go.dev/dl/?mode=json&include=all":     "http://proxy.example.com:3128",
+		"https://internal.example.com/go-dl/go.tar.gz": "",
+		"http://dl.google.com/go/go.tar.gz":            "",
+	} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got == nil && want != "" || got != nil && got.String() != want {
+			t.Errorf("%s: proxy %v, want %q", url, got, want)
+		}
+	}
+	if !strings.Contains(logs.String(), "proxy=http://proxy.example.com:3128") {
+		t.Errorf("proxy isn't logged: %s", logs.String())
+	}
+}
@@ -0,0 +1,75 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Import installs Go from release archive (go<version>.<os>-<arch>.tar.gz or .zip)
+// without network access. Version is read from VERSION file of the archive.
+func (m *Manager) Import(archive string, force bool) (*InstallResult, error) {
+	start := time.Now()
+	if err := os.MkdirAll(m.SDKDir, 0755); err != nil {
+		return nil, err
+	}
+	tmpDir, err := os.MkdirTemp(m.SDKDir, ".import-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	fmt.Fprintf(m.Stdout, "Unpack %s...\n", archive)
+	if err := unpackArchive(archive, tmpDir); err != nil {
+		return nil, fmt.Errorf("unpack of %s is failed: %w", archive, err)
+	}
+	version, err := goRootVersion(tmpDir)
+	if err != nil {
+		return nil, fmt.Errorf("version detection is failed: %w", err)
+	}
+
+	name := filepath.Base(archive)
+	isReleaseName := strings.HasPrefix(name, "go"+version+".")
+	if host := archiveFilename(version, runtime.GOOS, runtime.GOARCH); isReleaseName && name != host {
+		return nil, fmt.Errorf("%s is not archive for %s/%s (%s is expected)", name, runtime.GOOS, runtime.GOARCH, host)
+	}
+
+	sdkDir := m.sdkPath(version)
+	if _, err := os.Stat(filepath.Join(sdkDir, unpackedMarker)); err == nil && !force {
+		return nil, fmt.Errorf("Go %s is already installed to %s", version, sdkDir)
+	}
+	mf, err := newManifest(tmpDir, archive, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := mf.write(tmpDir); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, unpackedMarker), nil, 0644); err != nil {
+		return nil, err
+	}
+	// MkdirTemp creates directory accessible by owner only
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(sdkDir); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpDir, sdkDir); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(m.Stdout, "Go %s is installed to %s\n", version, sdkDir)
+
+	if err := m.registerSDK(m.Stdout, version); err != nil {
+		return nil, err
+	}
+	if isReleaseName {
+		if _, err := m.cacheArchive(archive, false); err != nil {
+			return nil, fmt.Errorf("archive caching is failed: %w", err)
+		}
+	}
+	return &InstallResult{Version: version, GOROOT: sdkDir, Duration: time.Since(start)}, nil
+}
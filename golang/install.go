@@ -2,39 +2,273 @@ package golang
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
 )
 
+// InstallResult is result of Go version install.
+type InstallResult struct {
+	Version string
+	// GOROOT is SDK directory of installed version.
+	GOROOT   string
+	Duration time.Duration
+	// Err is install error (InstallAll only).
+	Err error
+}
+
 // Install installs requested Golang version.
 // does:
 // go install golang.org/dl/go1.10.7@latest
-// go1.10.7 download
-func Install(version string, force bool) error {
-	fmt.Printf("Install helper tool for %v...\n", version)
-	if force {
-		if err := removeIfExists(version); err != nil {
+// and downloads Go release archive to ~/sdk/go1.10.7 (like `go1.10.7 download` does)
+func (m *Manager) Install(version string, opts InstallOpts) (*InstallResult, error) {
+	start := time.Now()
+	if err := m.install(m.Stdout, version, opts); err != nil {
+		return nil, err
+	}
+	return &InstallResult{Version: version, GOROOT: m.sdkPath(version), Duration: time.Since(start)}, nil
+}
+
+func (m *Manager) install(out io.Writer, version string, opts InstallOpts) error {
+	if !opts.IgnorePolicy {
+		if err := m.checkPolicy(version); err != nil {
+			return err
+		}
+	}
+	if opts.Force {
+		if err := m.removeIfExists(out, version); err != nil {
+			return err
+		}
+	}
+	if opts.Offline {
+		fmt.Fprintf(out, "Install Go version %v from local archive...\n", version)
+		if err := m.downloadSDK(out, version, opts); err != nil {
 			return err
 		}
+		return m.registerSDK(out, version)
 	}
-	if err := runCmd("go", "install", fmt.Sprintf("golang.org/dl/go%s@latest", version)); err != nil {
+
+	fmt.Fprintf(out, "Install helper tool for %v...\n", version)
+	// GOBIN keeps helper tools in GOPATH of Manager even if GOPATH is isolated by `go env -w`
+	cmd := m.command(out, "go", "install", fmt.Sprintf("golang.org/dl/go%s@latest", version))
+	cmd.Env = setEnv(os.Environ(), "GOBIN", m.binDir())
+	if err := m.runCommand(cmd); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Download Go version %v...\n", version)
+	return m.downloadSDK(out, version, opts)
+}
+
+// registerSDK makes downloaded SDK available as go<version> binary
+// without network access (symlink is used instead of golang.org/dl helper tool).
+func (m *Manager) registerSDK(out io.Writer, version string) error {
+	sdkDir := m.sdkPath(version)
+	wrapper := filepath.Join(m.binDir(), "go"+version)
+	if _, err := os.Lstat(wrapper); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(m.binDir(), 0755); err != nil {
 		return err
 	}
-	fmt.Printf("Download Go version %v...\n", version)
-	return runCmd(fmt.Sprintf("go%s", version), "download")
+	m.Logger.Debug("create symlink", "link", wrapper, "target", goBin(sdkDir))
+	if err := os.Symlink(goBin(sdkDir), wrapper); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "set symlink %s -> %s\n", wrapper, goBin(sdkDir))
+	return nil
 }
 
-func removeIfExists(version string) error {
-	homeDir, err := os.UserHomeDir()
+// unpackedMarker is created in SDK directory after successful unpack (like golang.org/dl does).
+const unpackedMarker = ".unpacked-success"
+
+// downloadSDK downloads and unpacks Go release archive to SDK directory
+// (does the same as `go<version> download`, but resumes and retries failed downloads).
+// Downloaded archives are cached and reused.
+func (m *Manager) downloadSDK(out io.Writer, version string, opts InstallOpts) error {
+	sdkDir := m.sdkPath(version)
+	if _, err := os.Stat(filepath.Join(sdkDir, unpackedMarker)); err == nil {
+		fmt.Fprintf(out, "Go %s is already downloaded to %s\n", version, sdkDir)
+		return m.verifyLockedSDK(version, sdkDir)
+	}
+
+	archive, err := m.fetchArchive(out, version, runtime.GOOS, runtime.GOARCH, opts)
+	if err != nil {
+		return err
+	}
+	archiveName := archiveFilename(version, runtime.GOOS, runtime.GOARCH)
+	if err := m.verifyLockedArchive(version, hostPlatform(), archiveName, archive); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Unpack %s to %s...\n", archive, sdkDir)
+	return unpackSDK(archive, archiveName, sdkDir)
+}
+
+// fetchArchive returns path to archive of Go version for goos/goarch
+// found in FromDir, in cache or downloaded to cache.
+func (m *Manager) fetchArchive(out io.Writer, version string, goos string, goarch string, opts InstallOpts) (string, error) {
+	if opts.Offline || opts.FromDir != "" {
+		archive, err := m.offlineArchive(archiveFilename(version, goos, goarch), opts.FromDir)
+		if err == nil || opts.Offline {
+			return archive, err
+		}
+	}
+
+	file, err := m.platformArchive(version, goos, goarch)
 	if err != nil {
-		return fmt.Errorf("user home dir resolving is failed: %w", err)
+		return "", err
+	}
+	archive, err := m.cachedArchive(file.Filename, file.Sha256)
+	if err != nil {
+		return "", err
+	}
+	if archive != "" {
+		fmt.Fprintf(out, "Use cached %s\n", archive)
+		return archive, nil
 	}
 
+	archive = filepath.Join(m.SDKDir, file.Filename)
+	if err := os.MkdirAll(filepath.Dir(archive), 0755); err != nil {
+		return "", err
+	}
+	d := m.newDownloader(out)
+	if err := d.download(m.DownloadBaseURL+file.Filename, archive, file.Size, file.Sha256); err != nil {
+		return "", err
+	}
+	return m.cacheArchive(archive, true)
+}
+
+// unpackSDK unpacks archive to sdkDir, writes manifest of SDK files and marks it as successfully unpacked.
+func unpackSDK(archive string, archiveName string, sdkDir string) error {
+	return unpackTo(archive, sdkDir, func(dir string) error {
+		mf, err := newManifest(dir, archive, archiveName)
+		if err != nil {
+			return err
+		}
+		if err := mf.write(dir); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, unpackedMarker), nil, 0644)
+	})
+}
+
+// unpackTo unpacks archive to dest replacing its content.
+// Archive is unpacked to temporary directory first, so dest never contains partial SDK,
+// prepare (if it isn't nil) is called for temporary directory before it's moved to dest.
+func unpackTo(archive string, dest string, prepare func(dir string) error) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dest), filepath.Base(dest)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := unpackArchive(archive, tmpDir); err != nil {
+		return fmt.Errorf("unpack of %s is failed: %w", archive, err)
+	}
+	if prepare != nil {
+		if err := prepare(tmpDir); err != nil {
+			return err
+		}
+	}
+	// MkdirTemp creates directory accessible by owner only, GOROOT must be readable by everyone
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, dest)
+}
+
+// InstallOpts controls Install and InstallAll behaviour.
+type InstallOpts struct {
+	// Force removes distro before fetch if it exists locally.
+	Force bool
+	// Offline installs from cached archives or FromDir only (without network access).
+	Offline bool
+	// FromDir is directory with Go release archives (go<version>.<os>-<arch>.tar.gz).
+	FromDir string
+	// Jobs limits number of concurrent installs.
+	Jobs int
+	// IgnorePolicy allows to install versions violating team policy (see Policy).
+	IgnorePolicy bool
+}
+
+// InstallAll installs requested Golang versions concurrently.
+// Output of every install is prefixed by version, failed install doesn't stop others.
+// Results are returned in order of versions, *InstallError is returned if some installs are failed.
+func (m *Manager) InstallAll(versions []string, opts InstallOpts) ([]InstallResult, error) {
+	jobs := opts.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+	// policy is loaded once before concurrent installs
+	if _, err := m.Policy(); err != nil {
+		return nil, err
+	}
+
+	results := make([]InstallResult, len(versions))
+	var (
+		outMu sync.Mutex
+		wg    sync.WaitGroup
+	)
+	sem := make(chan struct{}, jobs)
+	for i, version := range versions {
+		i, version := i, version
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			out := &prefixWriter{mu: &outMu, w: m.Stdout, prefix: "[" + version + "] "}
+			start := time.Now()
+			err := m.install(out, version, opts)
+			if err != nil {
+				fmt.Fprintf(out, "ERROR: %v\n", err)
+			}
+			out.Flush()
+			results[i] = InstallResult{Version: version, GOROOT: m.sdkPath(version), Duration: time.Since(start), Err: err}
+		}()
+	}
+	wg.Wait()
+
+	var failed []InstallResult
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) > 0 {
+		return results, &InstallError{Failed: failed, Total: len(versions)}
+	}
+	return results, nil
+}
+
+func (m *Manager) removeIfExists(out io.Writer, version string) error {
 	// parse downloaded Go SDK directories by `go install golang.org/dl/go1.*`
-	pathDlSDK := filepath.Join(homeDir, "sdk", "go"+version)
+	pathDlSDK := m.sdkPath(version)
 	if _, err := os.Stat(pathDlSDK); os.IsNotExist(err) {
 		return nil
 	}
-	fmt.Printf("os.RemoveAll(%s)\n", pathDlSDK)
+	fmt.Fprintf(out, "os.RemoveAll(%s)\n", pathDlSDK)
 	return os.RemoveAll(pathDlSDK)
 }
+
+// Uninstall removes Go SDK downloaded by `go<version> download` and go<version> binary.
+func (m *Manager) Uninstall(version string) error {
+	if err := m.removeIfExists(m.Stdout, version); err != nil {
+		return err
+	}
+	wrapper := filepath.Join(m.binDir(), "go"+version)
+	if _, err := os.Lstat(wrapper); os.IsNotExist(err) {
+		return nil
+	}
+	fmt.Fprintf(m.Stdout, "os.Remove(%s)\n", wrapper)
+	return os.Remove(wrapper)
+}
@@ -0,0 +1,164 @@
+package golang_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+func TestGetInstallsFromReleaseServer(t *testing.T) {
+	env, m := newTestEnv(t, "1.21.3", "1.22.1")
+	versions, err := m.ResolveVersions([]string{"1.22"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.InstallAll(versions, golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(env.SDKPath("1.22.1"), "VERSION"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "go1.22.1\n") {
+		t.Errorf("VERSION = %q, go1.22.1 is expected", b)
+	}
+	for _, file := range []string{
+		filepath.Join(env.SDKPath("1.22.1"), ".unpacked-success"),
+		filepath.Join(env.SDKPath("1.22.1"), "bin", "go"),
+		env.WrapperPath("1.22.1"),
+	} {
+		if _, err := os.Stat(file); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := os.Stat(env.SDKPath("1.21.3")); !os.IsNotExist(err) {
+		t.Errorf("Go 1.21.3 isn't requested, but its SDK exists: %v", err)
+	}
+
+	res, err := m.List(env.GoBinLink, golang.ListOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Installed) != 1 || res.Installed[0].Version != "1.22.1" {
+		t.Errorf("installed versions %+v, want 1.22.1", res.Installed)
+	}
+}
+
+func TestInstalledSDKIsReadableByEveryone(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(env.SDKPath("1.22.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := fi.Mode().Perm(); mode != 0755 {
+		t.Errorf("SDK directory mode is %v, want %v", mode, os.FileMode(0755))
+	}
+}
+
+// concurrencyTransport counts concurrent archive downloads, every download is delayed.
+type concurrencyTransport struct {
+	next http.RoundTripper
+
+	mu            sync.Mutex
+	active, limit int
+}
+
+func (c *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/" {
+		return c.next.RoundTrip(req)
+	}
+	c.mu.Lock()
+	c.active++
+	if c.active > c.limit {
+		c.limit = c.active
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.active--
+		c.mu.Unlock()
+	}()
+	time.Sleep(50 * time.Millisecond)
+	return c.next.RoundTrip(req)
+}
+
+func TestInstallAllLimitsConcurrency(t *testing.T) {
+	env, m := newTestEnv(t, "1.19.13", "1.20.14", "1.21.3", "1.22.1")
+	transport := &concurrencyTransport{next: m.HTTPClient.Transport}
+	m.HTTPClient = &http.Client{Transport: transport}
+
+	versions := []string{"1.22.1", "1.21.3", "1.20.14", "1.19.13"}
+	results, err := m.InstallAll(versions, golang.InstallOpts{Jobs: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.limit != 2 {
+		t.Errorf("%d concurrent downloads, want 2", transport.limit)
+	}
+	for i, r := range results {
+		if r.Version != versions[i] || r.Err != nil || r.GOROOT != env.SDKPath(versions[i]) {
+			t.Errorf("result %d: %+v, want installed %s", i, r, versions[i])
+		}
+	}
+}
+
+func TestInstallAllCollectsErrors(t *testing.T) {
+	env, m := newTestEnv(t, "1.21.3", "1.22.1")
+	var stdout bytes.Buffer
+	m.Stdout = &stdout
+
+	versions := []string{"1.21.3", "1.20.99", "1.22.1"}
+	results, err := m.InstallAll(versions, golang.InstallOpts{Jobs: 3})
+	var installErr *golang.InstallError
+	if !errors.As(err, &installErr) {
+		t.Fatalf("error = %v, *InstallError is expected", err)
+	}
+	if installErr.Total != 3 || len(installErr.Failed) != 1 || installErr.Failed[0].Version != "1.20.99" {
+		t.Errorf("install error %+v, failed 1.20.99 of 3 is expected", installErr)
+	}
+	requireContains(t, err.Error(), "1 of 3 installs failed: 1.20.99")
+	requireContains(t, stdout.String(), "[1.20.99] ERROR: ")
+
+	// failed install doesn't stop others
+	if len(results) != 3 || results[1].Err == nil {
+		t.Fatalf("results %+v", results)
+	}
+	for _, v := range []string{"1.21.3", "1.22.1"} {
+		if _, err := os.Stat(filepath.Join(env.SDKPath(v), "VERSION")); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestResolveMajorRelease(t *testing.T) {
+	_, m := newTestEnv(t, "1.17", "1.17.13", "1.18rc1", "1.21.0", "1.21.3")
+	tests := map[string]string{
+		"1.17":   "1.17.13",
+		"=1.17":  "1.17",
+		"1.21":   "1.21.3",
+		"1.21.0": "1.21.0",
+		"stable": "1.21.3",
+	}
+	for spec, want := range tests {
+		versions, err := m.ResolveVersions([]string{spec})
+		if err != nil {
+			t.Errorf("%s: %v", spec, err)
+			continue
+		}
+		if len(versions) != 1 || versions[0] != want {
+			t.Errorf("%s: %q, want %s", spec, versions, want)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IsolatedVars are Go environment variables which can be set to version-specific directory.
+var IsolatedVars = []string{"GOCACHE", "GOMODCACHE", "GOPATH"}
+
+func isIsolatedVar(name string) bool {
+	for _, v := range IsolatedVars {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isolationRoot returns directory of version-specific Go environment directories.
+func (m *Manager) isolationRoot() string {
+	return filepath.Join(m.CacheDir, "env")
+}
+
+// isolationDir returns version-specific directory of Go environment variable.
+func (m *Manager) isolationDir(version string, name string) string {
+	return filepath.Join(m.isolationRoot(), "go"+version, strings.ToLower(name))
+}
+
+// isolatedVars returns variables isolated for version: exact version setting has priority
+// over major release setting which has priority over "*".
+func (m *Manager) isolatedVars(version string) []string {
+	keys := []string{version}
+	if v, err := parseVersionInfo(version); err == nil {
+		keys = append(keys, majorMinor(v))
+	}
+	keys = append(keys, "*")
+	for _, key := range keys {
+		if vars, ok := m.Isolate[key]; ok {
+			return vars
+		}
+	}
+	return nil
+}
+
+// IsolatedEnv returns isolated Go environment variables of version ("NAME=dir", sorted by name).
+func (m *Manager) IsolatedEnv(version string) []string {
+	var env []string
+	for _, name := range m.isolatedVars(version) {
+		env = append(env, name+"="+m.isolationDir(version, name))
+	}
+	sort.Strings(env)
+	return env
+}
+
+// isIsolationPath reports whether path is inside isolation root.
+func (m *Manager) isIsolationPath(path string) bool {
+	rel, err := filepath.Rel(m.isolationRoot(), path)
+	return path != "" && err == nil && !strings.HasPrefix(rel, "..")
+}
+
+// applyIsolation writes isolated variables of version to Go environment configuration (`go env -w`)
+// and removes isolated values of other versions (`go env -u`). goBinary is go binary of version.
+func (m *Manager) applyIsolation(goBinary string, version string) (set []string, unset []string, err error) {
+	isolated := m.IsolatedEnv(version)
+	if len(isolated) == 0 {
+		if _, err := os.Stat(m.isolationRoot()); err != nil {
+			// isolation has never been used
+			return nil, nil, nil
+		}
+	}
+
+	var stdout bytes.Buffer
+	args := append([]string{"env", "-json"}, IsolatedVars...)
+	if err := m.runCommand(&Command{Name: goBinary, Args: args, Stdout: &stdout, Stderr: m.Stderr}); err != nil {
+		return nil, nil, err
+	}
+	current := map[string]string{}
+	if err := json.Unmarshal(stdout.Bytes(), &current); err != nil {
+		return nil, nil, fmt.Errorf("go env decoding is failed: %w", err)
+	}
+
+	want := map[string]string{}
+	for _, kv := range isolated {
+		name, dir := splitEnv(kv)
+		want[name] = dir
+	}
+	for _, name := range IsolatedVars {
+		dir, ok := want[name]
+		switch {
+		case ok && current[name] != dir:
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return set, unset, err
+			}
+			if err := m.run(m.Stdout, goBinary, "env", "-w", name+"="+dir); err != nil {
+				return set, unset, err
+			}
+			set = append(set, name+"="+dir)
+		case !ok && m.isIsolationPath(current[name]):
+			if err := m.run(m.Stdout, goBinary, "env", "-u", name); err != nil {
+				return set, unset, err
+			}
+			unset = append(unset, name)
+		}
+	}
+	return set, unset, nil
+}
+
+func splitEnv(kv string) (string, string) {
+	i := strings.IndexByte(kv, '=')
+	if i < 0 {
+		return kv, ""
+	}
+	return kv[:i], kv[i+1:]
+}
+
+// EnvVar is environment variable.
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// EnvResult is shell environment of Go version.
+type EnvResult struct {
+	Version string
+	GOROOT  string
+	// Set are variables to set: GOROOT, PATH (with GOROOT/bin first) and isolated variables.
+	Set []EnvVar
+	// Unset are isolated variables of other versions which are set in current environment.
+	Unset []string
+}
+
+// Env returns shell environment of Go version (version of linkPath symlink target if version is empty).
+func (m *Manager) Env(linkPath string, version string) (*EnvResult, error) {
+	var (
+		goRoot string
+		err    error
+	)
+	if version == "" {
+		goRoot, version, err = m.currentVersion(linkPath)
+	} else {
+		goRoot, err = m.binGOROOT("go" + version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &EnvResult{Version: version, GOROOT: goRoot}
+	res.Set = append(res.Set,
+		EnvVar{Name: "GOROOT", Value: goRoot},
+		EnvVar{Name: "PATH", Value: m.sdkPATH(goRoot, os.Getenv("PATH"))},
+	)
+	isolated := map[string]bool{}
+	for _, kv := range m.IsolatedEnv(version) {
+		name, dir := splitEnv(kv)
+		isolated[name] = true
+		res.Set = append(res.Set, EnvVar{Name: name, Value: dir})
+	}
+	for _, name := range IsolatedVars {
+		if !isolated[name] && m.isIsolationPath(os.Getenv(name)) {
+			res.Unset = append(res.Unset, name)
+		}
+	}
+	return res, nil
+}
+
+// sdkPATH returns path list with bin directory of goRoot first,
+// bin directories of other SDKs managed by golangver are removed.
+func (m *Manager) sdkPATH(goRoot string, path string) string {
+	sdkBin := filepath.Join(goRoot, "bin")
+	list := []string{sdkBin}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" || sameGOROOT(dir, sdkBin) {
+			continue
+		}
+		if filepath.Base(dir) == "bin" && m.isSDKDir(filepath.Dir(dir)) {
+			continue
+		}
+		list = append(list, dir)
+	}
+	return strings.Join(list, string(os.PathListSeparator))
+}
+
+// isSDKDir reports whether dir is SDK downloaded by helper tool or IDEA.
+func (m *Manager) isSDKDir(dir string) bool {
+	parent := filepath.Clean(filepath.Dir(dir))
+	return strings.HasPrefix(filepath.Base(dir), "go1") &&
+		(parent == filepath.Clean(m.SDKDir) || parent == filepath.Join(m.HomeDir, "go"))
+}
+
+// Exec runs command with environment of Go version (see Env).
+// Command is looked up in GOROOT/bin first. Stdin, Stdout and Stderr of Manager are used.
+func (m *Manager) Exec(linkPath string, version string, args []string) error {
+	env, err := m.Env(linkPath, version)
+	if err != nil {
+		return err
+	}
+	cmd, err := m.envCommand(env, args)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = m.Stdin
+	cmd.Stdout = m.Stdout
+	cmd.Stderr = m.Stderr
+	return m.runCommand(cmd)
+}
+
+// envCommand returns command with environment env (directories of isolated variables are created).
+func (m *Manager) envCommand(env *EnvResult, args []string) (*Command, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("command is not provided")
+	}
+
+	environ := os.Environ()
+	for _, v := range env.Set {
+		environ = setEnv(environ, v.Name, v.Value)
+		if isIsolatedVar(v.Name) {
+			if err := os.MkdirAll(v.Value, 0755); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, name := range env.Unset {
+		environ = setEnv(environ, name, "")
+	}
+
+	name := args[0]
+	if !strings.ContainsRune(name, os.PathSeparator) {
+		if bin := filepath.Join(env.GOROOT, "bin", name); fileExists(bin) {
+			name = bin
+		}
+	}
+	return &Command{Name: name, Args: args[1:], Env: environ}, nil
+}
+
+// setVar sets variable in Set list (replaces existing value) and removes it from Unset list.
+func (r *EnvResult) setVar(name string, value string) {
+	for i := range r.Set {
+		if r.Set[i].Name == name {
+			r.Set[i].Value = value
+			return
+		}
+	}
+	r.Set = append(r.Set, EnvVar{Name: name, Value: value})
+	unset := r.Unset[:0:0]
+	for _, n := range r.Unset {
+		if n != name {
+			unset = append(unset, n)
+		}
+	}
+	r.Unset = unset
+}
+
+// setEnv sets variable in environ, empty value removes it.
+func setEnv(environ []string, name string, value string) []string {
+	res := environ[:0:0]
+	for _, kv := range environ {
+		if n, _ := splitEnv(kv); n != name {
+			res = append(res, kv)
+		}
+	}
+	if value != "" {
+		res = append(res, name+"="+value)
+	}
+	return res
+}
+
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
@@ -0,0 +1,190 @@
+package golang_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+// newIsolationEnv returns fake environment with SDKs of versions,
+// isolated variables aren't inherited from environment of test.
+func newIsolationEnv(t *testing.T, versions ...string) (*golangtest.Env, *golang.Manager) {
+	t.Helper()
+	env, m := newTestEnv(t)
+	for _, v := range versions {
+		if err := env.AddSDK(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("GOCACHE", "")
+	t.Setenv("GOMODCACHE", "")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(env.Dir, "config"))
+	return env, m
+}
+
+// goEnvFile returns content of Go environment configuration written by `go env -w` of fake SDK.
+func goEnvFile(t *testing.T, env *golangtest.Env) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(env.Dir, "config", "go", "env"))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestIsolatedEnv(t *testing.T) {
+	_, m := newTestEnv(t)
+	dir := func(version, name string) string {
+		return name + "=" + filepath.Join(m.CacheDir, "env", "go"+version, strings.ToLower(name))
+	}
+	m.Isolate = map[string][]string{
+		"1.21.3": {"GOMODCACHE"},
+		"1.21":   {"GOMODCACHE", "GOCACHE"},
+		"*":      {"GOCACHE"},
+	}
+	tests := map[string][]string{
+		"1.21.3":     {dir("1.21.3", "GOMODCACHE")},
+		"1.21.5":     {dir("1.21.5", "GOCACHE"), dir("1.21.5", "GOMODCACHE")},
+		"1.22.1":     {dir("1.22.1", "GOCACHE")},
+		"1.23rc1":    {dir("1.23rc1", "GOCACHE")},
+		"not-parsed": {dir("not-parsed", "GOCACHE")},
+	}
+	for version, want := range tests {
+		if got := m.IsolatedEnv(version); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: %q, want %q", version, got, want)
+		}
+	}
+
+	delete(m.Isolate, "*")
+	if got := m.IsolatedEnv("1.22.1"); got != nil {
+		t.Errorf("1.22.1 without \"*\": %q, nothing is expected", got)
+	}
+}
+
+func TestUseAppliesIsolation(t *testing.T) {
+	env, m := newIsolationEnv(t, "1.21.3", "1.22.1")
+	m.Isolate = map[string][]string{
+		"1.21": {"GOCACHE", "GOMODCACHE"},
+		"*":    {"GOCACHE"},
+	}
+	dir := func(version, name string) string {
+		return filepath.Join(m.CacheDir, "env", "go"+version, strings.ToLower(name))
+	}
+
+	res, err := m.UseVersion(env.GoBinLink, "1.21.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSet := []string{"GOCACHE=" + dir("1.21.3", "GOCACHE"), "GOMODCACHE=" + dir("1.21.3", "GOMODCACHE")}
+	if !reflect.DeepEqual(res.GoEnvSet, wantSet) || len(res.GoEnvUnset) != 0 {
+		t.Errorf("go env set %q, unset %q, want set %q", res.GoEnvSet, res.GoEnvUnset, wantSet)
+	}
+	if goEnv := goEnvFile(t, env); !strings.Contains(goEnv, wantSet[0]+"\n") || !strings.Contains(goEnv, wantSet[1]+"\n") {
+		t.Errorf("go env file %q doesn't contain %q", goEnv, wantSet)
+	}
+	if _, err := os.Stat(dir("1.21.3", "GOMODCACHE")); err != nil {
+		t.Error(err)
+	}
+
+	// variable isolated for previous version only is unset
+	res, err = m.UseVersion(env.GoBinLink, "1.22.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSet = []string{"GOCACHE=" + dir("1.22.1", "GOCACHE")}
+	if !reflect.DeepEqual(res.GoEnvSet, wantSet) || !reflect.DeepEqual(res.GoEnvUnset, []string{"GOMODCACHE"}) {
+		t.Errorf("go env set %q, unset %q, want set %q, unset GOMODCACHE", res.GoEnvSet, res.GoEnvUnset, wantSet)
+	}
+	if goEnv := goEnvFile(t, env); goEnv != wantSet[0]+"\n" {
+		t.Errorf("go env file %q, want %q", goEnv, wantSet[0]+"\n")
+	}
+
+	res, err = m.UseVersion(env.GoBinLink, "1.22.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.GoEnvSet) != 0 || len(res.GoEnvUnset) != 0 {
+		t.Errorf("go env set %q, unset %q, nothing is expected for applied isolation", res.GoEnvSet, res.GoEnvUnset)
+	}
+
+	// isolation is removed from configuration
+	m.Isolate = nil
+	res, err = m.UseVersion(env.GoBinLink, "1.21.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.GoEnvSet) != 0 || !reflect.DeepEqual(res.GoEnvUnset, []string{"GOCACHE"}) {
+		t.Errorf("go env set %q, unset %q, want unset GOCACHE", res.GoEnvSet, res.GoEnvUnset)
+	}
+	if goEnv := goEnvFile(t, env); goEnv != "" {
+		t.Errorf("go env file %q, empty is expected", goEnv)
+	}
+}
+
+func TestUseWithoutIsolation(t *testing.T) {
+	env, m := newIsolationEnv(t, "1.22.1")
+	runner := golangtest.NewFakeRunner(env)
+	m.Runner = runner
+	if _, err := m.UseVersion(env.GoBinLink, "1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, call := range runner.Calls() {
+		if strings.Contains(call, " env -w") || strings.Contains(call, " env -u") || strings.Contains(call, "GOCACHE") {
+			t.Errorf("go env is run without isolation: %s", call)
+		}
+	}
+}
+
+func TestEnvAndExec(t *testing.T) {
+	env, m := newIsolationEnv(t, "1.21.3", "1.22.1")
+	if err := env.Use("1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	m.Isolate = map[string][]string{"*": {"GOCACHE"}}
+	otherModCache := filepath.Join(m.CacheDir, "env", "go1.21.3", "gomodcache")
+	t.Setenv("GOMODCACHE", otherModCache)
+	goRoot := env.SDKPath("1.22.1")
+	goCache := filepath.Join(m.CacheDir, "env", "go1.22.1", "gocache")
+
+	res, err := m.Env(env.GoBinLink, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Version != "1.22.1" || res.GOROOT != goRoot {
+		t.Fatalf("version %s, GOROOT %s, want 1.22.1, %s", res.Version, res.GOROOT, goRoot)
+	}
+	set := map[string]string{}
+	for _, v := range res.Set {
+		set[v.Name] = v.Value
+	}
+	if set["GOROOT"] != goRoot || set["GOCACHE"] != goCache ||
+		!strings.HasPrefix(set["PATH"], filepath.Join(goRoot, "bin")+string(os.PathListSeparator)) {
+		t.Errorf("set %+v", res.Set)
+	}
+	if !reflect.DeepEqual(res.Unset, []string{"GOMODCACHE"}) {
+		t.Errorf("unset %q, want GOMODCACHE of other version", res.Unset)
+	}
+
+	var stdout bytes.Buffer
+	m.Stdout = &stdout
+	if err := m.Exec(env.GoBinLink, "1.21.3", []string{"go", "env", "GOROOT", "GOCACHE", "GOMODCACHE"}); err != nil {
+		t.Fatal(err)
+	}
+	want := env.SDKPath("1.21.3") + "\n" + filepath.Join(m.CacheDir, "env", "go1.21.3", "gocache") + "\n\n"
+	if stdout.String() != want {
+		t.Errorf("go env output %q, want %q", stdout.String(), want)
+	}
+	if _, err := os.Stat(filepath.Join(m.CacheDir, "env", "go1.21.3", "gocache")); err != nil {
+		t.Error(err)
+	}
+
+	if err := m.Exec(env.GoBinLink, "", nil); err == nil {
+		t.Error("error is expected without command")
+	}
+}
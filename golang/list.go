@@ -1,12 +1,8 @@
 package golang
 
 import (
-	"bufio"
-	"bytes"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -22,25 +18,71 @@ type ListOpts struct {
 	ShowAllRemotes bool
 	// ShowOutdated adds to list even old Go versions (older than 1.13).
 	ShowOutdated bool
+	// ShowStatus marks versions as supported, EOL or superseded by patch release (implied by ShowRemotes).
+	ShowStatus bool
 }
 
-// List shows Go versions available locally and remotely.
-func List(linkPath string, opts ListOpts) error {
-	currentTarget, err := goBinCheckSymlink(linkPath)
-	if err != nil {
-		return fmt.Errorf("check symlink %s is failed: %w", linkPath, err)
+// LocalVersion is Go version available locally.
+type LocalVersion struct {
+	Version string
+	GoBin   string
+	// Current is set for version which Go binary symlink points at.
+	Current bool
+	// Status is support status ("supported", "EOL", "pre-release", "superseded by <version>"),
+	// it's set only if remote versions are fetched.
+	Status string
+	// Policy is team policy status ("allowed" or "not allowed: <reasons>"), it's empty if there is no policy.
+	Policy string
+}
+
+// RemoteVersion is Go version available for download.
+type RemoteVersion struct {
+	Version string
+	Status  string
+	// Policy is team policy status (see LocalVersion.Policy).
+	Policy string
+	// ReleaseNotes is URL of release notes of major release (set for its first listed version only).
+	ReleaseNotes string
+}
+
+// ListResult is result of List.
+type ListResult struct {
+	// CurrentTarget is Go binary symlink target.
+	CurrentTarget string
+	// Installed are versions downloaded by golang.org/dl helper tools.
+	Installed []LocalVersion
+	// IDEA are versions downloaded by IDEA.
+	IDEA []LocalVersion
+	// Remote are versions available for download (set if ListOpts.ShowRemotes is set).
+	Remote []RemoteVersion
+	// Warning is about current version support status (EOL or missing security fixes).
+	Warning string
+}
+
+// CurrentFound reports whether Go binary symlink points at one of local versions.
+func (r *ListResult) CurrentFound() bool {
+	for _, vl := range [][]LocalVersion{r.Installed, r.IDEA} {
+		for _, v := range vl {
+			if v.Current {
+				return true
+			}
+		}
 	}
+	return false
+}
 
-	homeDir, err := os.UserHomeDir()
+// List returns Go versions available locally and remotely.
+func (m *Manager) List(linkPath string, opts ListOpts) (*ListResult, error) {
+	currentTarget, err := m.goBinCheckSymlink(linkPath)
 	if err != nil {
-		return fmt.Errorf("user home dir resolving is failed: %w", err)
+		return nil, fmt.Errorf("check symlink %s is failed: %w", linkPath, err)
 	}
 
 	// parse downloaded by IDEA SDK directories
-	pathIdeaSDK := filepath.Join(homeDir, "go")
+	pathIdeaSDK := filepath.Join(m.HomeDir, "go")
 	namesIDEA, err := filepath.Glob(filepath.Join(pathIdeaSDK, "go1.*"))
 	if err != nil {
-		return fmt.Errorf("list of %v is failed: %w", pathIdeaSDK, err)
+		return nil, fmt.Errorf("list of %v is failed: %w", pathIdeaSDK, err)
 	}
 
 	var ideaVersions versionList
@@ -51,7 +93,7 @@ func List(linkPath string, opts ListOpts) error {
 		name = name[2:]
 		v, err := parseVersionInfo(name)
 		if err != nil {
-			return fmt.Errorf("parse version failed %v: %w", name, err)
+			return nil, fmt.Errorf("parse version failed %v: %w", name, err)
 		}
 		v.binPath = goBinPath
 		ideaVersions = append(ideaVersions, *v)
@@ -59,114 +101,137 @@ func List(linkPath string, opts ListOpts) error {
 	ideaVersions.Sort()
 
 	// go* binaries downloaded by `go install go*`
+	dlVersions, err := m.installedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	// remote versions are required to detect support status
+	var remotes versionList
+	if opts.ShowRemotes || opts.ShowStatus {
+		remotes, err = m.remoteVersions()
+		if err != nil {
+			return nil, err
+		}
+	}
+	latest := latestStable(remotes)
+	policy, err := m.Policy()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ListResult{CurrentTarget: currentTarget}
+	var currentVersion *versionInfo
+	localVersions := func(vl versionList) []LocalVersion {
+		var lv []LocalVersion
+		for i, v := range vl {
+			l := LocalVersion{Version: v.original, GoBin: v.binPath}
+			if currentVersion == nil && v.binPath == currentTarget {
+				currentVersion = &vl[i]
+				l.Current = true
+			}
+			if latest != nil {
+				l.Status = versionStatus(&vl[i], remotes, latest)
+			}
+			l.Policy = policyStatus(policy, v.original)
+			lv = append(lv, l)
+		}
+		return lv
+	}
+	res.Installed = localVersions(dlVersions)
+	res.IDEA = localVersions(ideaVersions)
+
+	if currentVersion != nil && latest != nil {
+		res.Warning = currentStatusWarning(currentVersion, remotes, latest)
+	}
+	if opts.ShowRemotes {
+		res.Remote = remoteGoVersions(remotes, opts.ShowAllRemotes, opts.ShowOutdated)
+		for i := range res.Remote {
+			res.Remote[i].Policy = policyStatus(policy, res.Remote[i].Version)
+		}
+	}
+	return res, nil
+}
+
+// installedVersions returns versions downloaded by golang.org/dl helper tools (newest first).
+func (m *Manager) installedVersions() (versionList, error) {
 	var dlVersions versionList
 	// parse downloaded Go SDK directories by `go install golang.org/dl/go1.*`
-	namesDl, err := filepath.Glob(filepath.Join(golangBinDir(), "go1.*"))
+	namesDl, err := filepath.Glob(filepath.Join(m.binDir(), "go1.*"))
 	if err != nil {
-		return fmt.Errorf("list of %v is failed: %w", golangBinDir(), err)
+		return nil, fmt.Errorf("list of %v is failed: %w", m.binDir(), err)
 	}
 	for _, binPath := range namesDl {
 		_, name := filepath.Split(binPath)
-		goBinPath, err := parseGolangBin(binPath)
+		goBinPath, err := m.parseGolangBin(binPath)
 		if err != nil {
 			if errors.Is(err, ErrNotDownloaded) {
 				continue
 			}
-			return fmt.Errorf("go bin path detection failed: %w", err)
+			return nil, fmt.Errorf("go bin path detection failed: %w", err)
 		}
 
 		name = name[2:]
 		v, err := parseVersionInfo(name)
 		if err != nil {
-			return fmt.Errorf("failed parse version %v: %w", name, err)
+			return nil, fmt.Errorf("failed parse version %v: %w", name, err)
 		}
 		v.binPath = goBinPath
 		dlVersions = append(dlVersions, *v)
 	}
-
 	dlVersions.Sort()
-	var currentFound bool
-	printVersions := func(vl versionList) {
-		for _, v := range vl {
-			mark := " "
-			if !currentFound && v.binPath == currentTarget {
-				currentFound = true
-				mark = "*"
-			}
-			out := fmt.Sprintf("%s %-10s  %s", mark, v.original, v.binPath)
-			fmt.Println(out)
-		}
-	}
-
-	fmt.Println(" downloaded by `go install`:")
-	printVersions(dlVersions)
-	if len(ideaVersions) > 0 {
-		fmt.Print("\n downloaded by IDEA:\n")
-		printVersions(ideaVersions)
-	}
-
-	if !currentFound {
-		fmt.Println("currentTarget:", currentTarget)
-	}
-
-	if opts.ShowRemotes {
-		return showRemoteGoVersions(opts.ShowAllRemotes, opts.ShowOutdated)
-	}
-	return nil
+	return dlVersions, nil
 }
 
 var lastNonOutdatedVersion = "1.13.0"
 
-func showRemoteGoVersions(showAll bool, showOutdated bool) error {
-	var output bytes.Buffer
-	gitArgs := []string{"ls-remote", "-t", "https://github.com/golang/go"}
-	cmd := exec.Command("git", gitArgs...)
-	cmd.Stdout = &output
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("command git %s failed: %w", strings.Join(gitArgs, " "), err)
+// remoteVersions returns Go versions from releases index (newest first).
+func (m *Manager) remoteVersions() (versionList, error) {
+	releases, err := m.releaseIndex()
+	if err != nil {
+		return nil, err
 	}
+	return m.releaseVersions(releases), nil
+}
 
-	var prefix = "refs/tags/go"
-	var minVersion = semver.New(lastNonOutdatedVersion) // September 2019
-
-	// var versions []*semver.Version
-	var foundMaxVersion *semver.Version
+// releaseVersions returns versions of releases (newest first).
+func (m *Manager) releaseVersions(releases []release) versionList {
 	var versions versionList
-	scanner := bufio.NewScanner(&output)
-	for scanner.Scan() {
-		parts := strings.Split(scanner.Text(), "\t")
-		if len(parts) != 2 {
-			continue
-		}
-		name := parts[1]
-		if !strings.HasPrefix(name, prefix) {
-			continue
-		}
-
-		name = name[len(prefix):]
+	for _, r := range releases {
+		name := strings.TrimPrefix(r.Version, "go")
 		v, err := parseVersionInfo(name)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "parseVersionInfo err=%v\n", err)
+			m.Logger.Warn("version parsing is failed", "version", r.Version, "err", err)
 			continue
 		}
+		versions = append(versions, *v)
+	}
+	versions.Sort()
+	return versions
+}
+
+func remoteGoVersions(allVersions versionList, showAll bool, showOutdated bool) []RemoteVersion {
+	latest := latestStable(allVersions)
+	var minVersion = semver.New(lastNonOutdatedVersion) // September 2019
+
+	var foundMaxVersion *semver.Version
+	var versions versionList
+	for _, v := range allVersions {
 		if !showOutdated && v.semver.LessThan(*minVersion) {
 			continue
 		}
-		versions = append(versions, *v)
+		versions = append(versions, v)
 
 		if foundMaxVersion == nil || foundMaxVersion.LessThan(*v.semver) {
 			foundMaxVersion = v.semver
 		}
 	}
 
-	versions.Sort()
-	fmt.Print("\n# remote Go versions:\n")
-
 	// "https://golang.org/doc/go1.17"
+	var remotes []RemoteVersion
 	var lastMinor int64
-	for _, v := range versions {
-		var extraInfo = ""
+	for i, v := range versions {
+		var releaseNotes = ""
 		if v.betaSuffix != "" && v.semver.Minor != foundMaxVersion.Minor {
 			continue
 		}
@@ -182,15 +247,18 @@ func showRemoteGoVersions(showAll bool, showOutdated bool) error {
 				suffix = "." + strconv.Itoa(int(lastMinor))
 			}
 			if v.betaSuffix == "" {
-				extraInfo = "\thttps://golang.org/doc/devel/release#go1" + suffix
+				releaseNotes = "https://golang.org/doc/devel/release#go1" + suffix
 			}
 		}
 		if v.betaSuffix != "" && v.semver.Minor == 18 {
-			extraInfo = "\thttps://go.dev/blog/go" + v.original
+			releaseNotes = "https://go.dev/blog/go" + v.original
 		}
 
-		fmt.Printf("  %s%s\n", v.original, extraInfo)
+		remotes = append(remotes, RemoteVersion{
+			Version:      v.original,
+			Status:       versionStatus(&versions[i], allVersions, latest),
+			ReleaseNotes: releaseNotes,
+		})
 	}
-
-	return nil
+	return remotes
 }
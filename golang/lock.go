@@ -0,0 +1,184 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// LockFile is name of lock file in project root, it pins Go version and checksums of its release archives.
+const LockFile = "golangver.lock"
+
+// Lock pins Go version and sha256 of its release archives per platform.
+type Lock struct {
+	Version string `json:"version"`
+	// Archives maps platform ("linux/amd64") to release archive.
+	Archives map[string]LockedArchive `json:"archives"`
+}
+
+// LockedArchive is Go release archive pinned by lock file.
+type LockedArchive struct {
+	Filename string `json:"filename"`
+	SHA256   string `json:"sha256"`
+}
+
+// Platforms returns sorted platforms of lock.
+func (l *Lock) Platforms() []string {
+	platforms := make([]string, 0, len(l.Archives))
+	for p := range l.Archives {
+		platforms = append(platforms, p)
+	}
+	sort.Strings(platforms)
+	return platforms
+}
+
+// LockOpts controls Lock behaviour.
+type LockOpts struct {
+	// Version is version spec to lock (see ResolveVersions), current version by default.
+	Version string
+	// Platforms are platforms ("linux/arm64") to lock archives of,
+	// current platform and platforms of existing lock file by default.
+	Platforms []string
+}
+
+// LockResult is result of Lock.
+type LockResult struct {
+	File string
+	Lock *Lock
+	// Created is set if lock file hasn't existed.
+	Created bool
+}
+
+// Lock writes lock file with Go version and sha256 of its release archives from releases index.
+func (m *Manager) Lock(linkPath string, opts LockOpts) (*LockResult, error) {
+	version := opts.Version
+	if version == "" {
+		_, current, err := m.currentVersion(linkPath)
+		if err != nil {
+			return nil, err
+		}
+		version = current
+	} else {
+		versions, err := m.ResolveVersions([]string{version})
+		if err != nil {
+			return nil, err
+		}
+		version = versions[0]
+	}
+
+	previous, err := m.readLock()
+	if err != nil {
+		return nil, err
+	}
+	platforms := opts.Platforms
+	if len(platforms) == 0 {
+		platforms = []string{hostPlatform()}
+		if previous != nil {
+			platforms = append(platforms, previous.Platforms()...)
+		}
+	}
+
+	lock := &Lock{Version: version, Archives: map[string]LockedArchive{}}
+	for _, platform := range platforms {
+		parts := strings.SplitN(platform, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("platform %q must be <os>/<arch>", platform)
+		}
+		file, err := m.platformArchive(version, parts[0], parts[1])
+		if err != nil {
+			return nil, err
+		}
+		lock.Archives[platform] = LockedArchive{Filename: file.Filename, SHA256: file.Sha256}
+	}
+
+	b, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	file := m.projectPath(LockFile)
+	if err := writeFileAtomic(file, append(b, '\n')); err != nil {
+		return nil, err
+	}
+	return &LockResult{File: file, Lock: lock, Created: previous == nil}, nil
+}
+
+// readLock reads lock file of project, returns nil if it doesn't exist.
+func (m *Manager) readLock() (*Lock, error) {
+	file := m.projectPath(LockFile)
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	lock := &Lock{}
+	if err := json.Unmarshal(b, lock); err != nil {
+		return nil, fmt.Errorf("%s parsing is failed: %w", file, err)
+	}
+	return lock, nil
+}
+
+// hostPlatform returns current platform as "<os>/<arch>".
+func hostPlatform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+// lockedArchive returns archive of version for platform pinned by lock file of project
+// (nil if lock file doesn't exist or pins other version).
+func (m *Manager) lockedArchive(version string, platform string) (*LockedArchive, error) {
+	lock, err := m.readLock()
+	if err != nil || lock == nil || lock.Version != version {
+		return nil, err
+	}
+	archive, ok := lock.Archives[platform]
+	if !ok {
+		return nil, fmt.Errorf("%s doesn't pin archive of Go %s for %s (run `golangver lock --platform %s`)",
+			LockFile, version, platform, platform)
+	}
+	return &archive, nil
+}
+
+// verifyLockedArchive checks archive of version for platform against lock file of project before unpack.
+func (m *Manager) verifyLockedArchive(version string, platform string, archiveName string, archive string) error {
+	locked, err := m.lockedArchive(version, platform)
+	if err != nil || locked == nil {
+		return err
+	}
+	m.Logger.Debug("verify archive against lock file", "archive", archiveName, "sha256", locked.SHA256)
+	if locked.Filename != archiveName {
+		return fmt.Errorf("%s pins archive %s instead of %s", LockFile, locked.Filename, archiveName)
+	}
+	if err := verifyChecksum(archive, locked.SHA256); err != nil {
+		return fmt.Errorf("%s doesn't match %s: %w", archiveName, LockFile, err)
+	}
+	return nil
+}
+
+// verifyLockedSDK checks installed SDK against lock file of project:
+// it must be installed from pinned archive and must not be modified after install.
+func (m *Manager) verifyLockedSDK(version string, sdkDir string) error {
+	locked, err := m.lockedArchive(version, hostPlatform())
+	if err != nil || locked == nil {
+		return err
+	}
+	mf, diff, err := m.checkSDK(sdkDir)
+	if err != nil {
+		return err
+	}
+	if mf == nil {
+		return fmt.Errorf("Go %s is installed without manifest, it can't be verified against %s (reinstall it with `golangver get -f %s`)",
+			version, LockFile, version)
+	}
+	if mf.ArchiveSHA256 != locked.SHA256 {
+		return fmt.Errorf("Go %s is installed from archive with sha256 %s, %s pins %s",
+			version, mf.ArchiveSHA256, LockFile, locked.SHA256)
+	}
+	if !diff.Empty() {
+		return fmt.Errorf("Go %s is modified after install: %s", version, diff)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package golang_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+const badSHA256 = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// lockVersion writes lock file of version, sha256 of host archive is replaced if sha256 isn't empty.
+func lockVersion(t *testing.T, env *golangtest.Env, m *golang.Manager, version string, sha256 string) {
+	t.Helper()
+	res, err := m.Lock(env.GoBinLink, golang.LockOpts{Version: version})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sha256 == "" {
+		return
+	}
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	archive := res.Lock.Archives[platform]
+	archive.SHA256 = sha256
+	res.Lock.Archives[platform] = archive
+	b, err := json.Marshal(res.Lock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.WriteProjectFile(golang.LockFile, string(b)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetRefusesArchiveNotMatchingLock(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	lockVersion(t, env, m, "1.22.1", badSHA256)
+
+	_, err := m.Install("1.22.1", golang.InstallOpts{})
+	if err == nil {
+		t.Fatal("install error is expected")
+	}
+	requireContains(t, err.Error(), golang.LockFile)
+	if _, err := os.Stat(env.SDKPath("1.22.1")); !os.IsNotExist(err) {
+		t.Errorf("SDK is unpacked: %v", err)
+	}
+}
+
+func TestGetRefusesInstalledSDKNotMatchingLock(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+
+	lockVersion(t, env, m, "1.22.1", "")
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatalf("installed SDK matching lock: %v", err)
+	}
+
+	lockVersion(t, env, m, "1.22.1", badSHA256)
+	_, err := m.Install("1.22.1", golang.InstallOpts{})
+	if err == nil {
+		t.Fatal("install error is expected for SDK installed from other archive")
+	}
+	requireContains(t, err.Error(), "is installed from archive with sha256")
+
+	lockVersion(t, env, m, "1.22.1", "")
+	if err := os.WriteFile(filepath.Join(env.SDKPath("1.22.1"), "src", "go.mod"), []byte("module std // edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.Install("1.22.1", golang.InstallOpts{})
+	if err == nil {
+		t.Fatal("install error is expected for modified SDK")
+	}
+	requireContains(t, err.Error(), "is modified after install")
+}
+
+func TestDoctorReportsModifiedSDK(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Use("1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	lockVersion(t, env, m, "1.22.1", "")
+	if _, err := m.Doctor(env.GoBinLink); err != nil {
+		t.Fatalf("doctor of SDK matching lock: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(env.SDKPath("1.22.1"), "src", "go.mod"), []byte("module std // edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	res, err := m.Doctor(env.GoBinLink)
+	var problems *golang.ProblemsError
+	if !errors.As(err, &problems) {
+		t.Fatalf("error = %v, *ProblemsError is expected", err)
+	}
+	if res.SDKDiff == nil || len(res.SDKDiff.Modified) != 1 || res.SDKDiff.Modified[0] != "src/go.mod" {
+		t.Errorf("SDK diff %+v, modified src/go.mod is expected", res.SDKDiff)
+	}
+	requireContains(t, strings.Join(problems.Problems, "\n"), "is modified after install")
+
+	lockVersion(t, env, m, "1.22.1", badSHA256)
+	_, err = m.Doctor(env.GoBinLink)
+	if !errors.As(err, &problems) {
+		t.Fatalf("error = %v, *ProblemsError is expected", err)
+	}
+	requireContains(t, strings.Join(problems.Problems, "\n"), golang.LockFile)
+}
@@ -0,0 +1,120 @@
+package golang
+
+import (
+	"fmt"
+	"go/build"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Manager manages Go versions installed locally.
+// Zero value isn't usable, use NewManager.
+type Manager struct {
+	// Stdin is stdin of commands run by Exec.
+	Stdin io.Reader
+	// Stdout receives progress output: output of external commands, downloads progress,
+	// details shown before prompts.
+	Stdout io.Writer
+	// Stderr receives stderr of external commands and warnings.
+	Stderr io.Writer
+	// Prompter asks user for confirmations (patch of go.mod, editor settings, etc).
+	Prompter Prompter
+	// Runner runs external commands.
+	Runner Runner
+	// Logger receives debug logs.
+	Logger *slog.Logger
+
+	// HomeDir is user home directory (SDKs downloaded by IDEA are in <HomeDir>/go).
+	HomeDir string
+	// GOPATH is Go path, golang.org/dl helper tools are installed to <GOPATH>/bin.
+	GOPATH string
+	// SDKDir is directory of SDKs downloaded by golang.org/dl helper tools (<HomeDir>/sdk).
+	SDKDir string
+	// CacheDir is golangver cache directory.
+	CacheDir string
+	// ProjectDir is directory of project which settings are patched and checked.
+	ProjectDir string
+
+	// HTTPClient is used for all HTTP requests.
+	HTTPClient *http.Client
+	// ReleaseIndexURL is URL of Go releases index (JSON).
+	ReleaseIndexURL string
+	// DownloadBaseURL is base URL of Go release archives.
+	DownloadBaseURL string
+	// IndexTTL is time cached releases index is used without fetch
+	// (zero means index is always fetched, cached one is used only if fetch fails).
+	IndexTTL time.Duration
+	// RefreshIndex forces fetch of releases index even if cached one is fresh.
+	RefreshIndex bool
+	// ModuleProxy is base URL of Go module proxy golangver releases are checked in (https://proxy.golang.org by default),
+	// if it's set, it's GOPROXY of `go install` run by SelfUpdate too.
+	ModuleProxy string
+
+	// Editors are editor integrations used by UseVersion, Doctor and Check.
+	Editors []EditorIntegration
+
+	// Isolate maps Go version, major release or "*" to isolated Go environment variables (see Config.Isolate).
+	Isolate map[string][]string
+
+	// PolicySource is path or URL of team policy file (see Policy),
+	// <ProjectDir>/.golangver-policy.json is used if it's empty.
+	PolicySource string
+	// policy is loaded policy (nil if policy file doesn't exist), see loadPolicy.
+	policy       *Policy
+	policyLoaded bool
+}
+
+const (
+	defaultReleaseIndexURL = "https://go.dev/dl/?mode=json&include=all"
+	defaultDownloadBaseURL = "https://dl.google.com/go/"
+)
+
+// NewManager returns Manager for current user which doesn't print (output is discarded)
+// and doesn't prompt (default answers are used).
+func NewManager() (*Manager, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("user home dir resolving is failed: %w", err)
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("user cache dir resolving is failed: %w", err)
+	}
+
+	return &Manager{
+		Stdout:          io.Discard,
+		Stderr:          io.Discard,
+		Prompter:        DefaultAnswers{},
+		Runner:          ExecRunner{},
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		HomeDir:         homeDir,
+		GOPATH:          build.Default.GOPATH,
+		SDKDir:          filepath.Join(homeDir, "sdk"),
+		CacheDir:        filepath.Join(userCacheDir, "golangver"),
+		ProjectDir:      ".",
+		HTTPClient:      http.DefaultClient,
+		ReleaseIndexURL: defaultReleaseIndexURL,
+		DownloadBaseURL: defaultDownloadBaseURL,
+		IndexTTL:        defaultIndexTTL,
+		Editors:         DefaultEditors(homeDir),
+	}, nil
+}
+
+// binDir returns directory of golang.org/dl helper tools.
+func (m *Manager) binDir() string {
+	return filepath.Join(m.GOPATH, "bin")
+}
+
+// sdkPath returns path of SDK directory for `go<version>` binary installed by `go install golang.org/dl/go<version>`.
+func (m *Manager) sdkPath(version string) string {
+	return filepath.Join(m.SDKDir, "go"+version)
+}
+
+// projectPath returns path of file in project directory.
+func (m *Manager) projectPath(name string) string {
+	return filepath.Join(m.ProjectDir, name)
+}
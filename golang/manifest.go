@@ -0,0 +1,160 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// manifestFile is created in SDK directory at install time, it lists SDK files with their hashes.
+const manifestFile = ".golangver-manifest.json"
+
+// Manifest lists files of installed SDK with sha256 of their content.
+type Manifest struct {
+	Version string `json:"version"`
+	// Archive is file name of release archive SDK is installed from.
+	Archive       string `json:"archive"`
+	ArchiveSHA256 string `json:"archive_sha256"`
+	// Files maps slash-separated paths relative to SDK directory to sha256 of content
+	// (symlinks are mapped to "symlink:<target>").
+	Files map[string]string `json:"files"`
+}
+
+// newManifest returns manifest of SDK unpacked to dir from archive named archiveName.
+func newManifest(dir string, archive string, archiveName string) (*Manifest, error) {
+	version, err := goRootVersion(dir)
+	if err != nil {
+		return nil, fmt.Errorf("version detection is failed: %w", err)
+	}
+	archiveSum, err := fileSHA256(archive)
+	if err != nil {
+		return nil, err
+	}
+	files, err := sdkFileHashes(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Manifest{Version: version, Archive: archiveName, ArchiveSHA256: archiveSum, Files: files}, nil
+}
+
+func (mf *Manifest) write(dir string) error {
+	b, err := json.MarshalIndent(mf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, manifestFile), append(b, '\n'))
+}
+
+// readManifest reads manifest of SDK, returns nil if SDK doesn't have manifest
+// (it's installed by older golangver, by golang.org/dl helper tool or by IDEA).
+func readManifest(goRoot string) (*Manifest, error) {
+	file := filepath.Join(goRoot, manifestFile)
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	mf := &Manifest{}
+	if err := json.Unmarshal(b, mf); err != nil {
+		return nil, fmt.Errorf("%s parsing is failed: %w", file, err)
+	}
+	return mf, nil
+}
+
+// sdkFileHashes returns hashes of files in SDK directory (golangver and golang.org/dl markers are skipped).
+func sdkFileHashes(dir string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() || rel == unpackedMarker || rel == manifestFile {
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			files[rel] = "symlink:" + filepath.ToSlash(target)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return err
+		}
+		files[rel] = sum
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hashing of %s files is failed: %w", dir, err)
+	}
+	return files, nil
+}
+
+// SDKDiff is difference between SDK files and its manifest.
+type SDKDiff struct {
+	// Added, Modified and Missing are slash-separated paths relative to SDK directory.
+	Added    []string
+	Modified []string
+	Missing  []string
+}
+
+// Empty reports whether SDK matches its manifest.
+func (d *SDKDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Modified) == 0 && len(d.Missing) == 0
+}
+
+func (d *SDKDiff) String() string {
+	return fmt.Sprintf("%d added, %d modified, %d missing files", len(d.Added), len(d.Modified), len(d.Missing))
+}
+
+// diffFiles compares actual file hashes of SDK with expected ones.
+func diffFiles(expected, actual map[string]string) *SDKDiff {
+	d := &SDKDiff{}
+	for name, sum := range actual {
+		want, ok := expected[name]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, name)
+		case want != sum:
+			d.Modified = append(d.Modified, name)
+		}
+	}
+	for name := range expected {
+		if _, ok := actual[name]; !ok {
+			d.Missing = append(d.Missing, name)
+		}
+	}
+	sort.Strings(d.Added)
+	sort.Strings(d.Modified)
+	sort.Strings(d.Missing)
+	return d
+}
+
+// checkSDK compares files of SDK with its manifest, returns nil manifest and diff if SDK doesn't have manifest.
+func (m *Manager) checkSDK(goRoot string) (*Manifest, *SDKDiff, error) {
+	mf, err := readManifest(goRoot)
+	if err != nil || mf == nil {
+		return nil, nil, err
+	}
+	m.Logger.Debug("check SDK files", "goroot", goRoot, "files", len(mf.Files))
+	files, err := sdkFileHashes(goRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mf, diffFiles(mf.Files, files), nil
+}
@@ -0,0 +1,274 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MatrixOpts controls Matrix behaviour.
+type MatrixOpts struct {
+	// Versions are version specs resolved against installed versions:
+	// exact version, major release (1.21 is the newest installed 1.21.x), stable/latest (the newest installed stable)
+	// or constraint (>=1.21).
+	Versions []string
+	// Jobs limits number of concurrent runs.
+	Jobs int
+}
+
+// MatrixRun is result of command run with Go version.
+type MatrixRun struct {
+	Version  string
+	Duration time.Duration
+	// ExitCode is exit code of command (-1 if command isn't started).
+	ExitCode int
+	Err      error
+	// Output is combined stdout and stderr of command.
+	Output string
+}
+
+// Passed reports whether command succeeded.
+func (r *MatrixRun) Passed() bool {
+	return r.Err == nil
+}
+
+// ResolveInstalledVersions resolves version specs against versions downloaded by helper tools.
+func (m *Manager) ResolveInstalledVersions(specs []string) ([]string, error) {
+	installed, err := m.installedVersions()
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		spec = strings.TrimPrefix(strings.TrimSpace(spec), "v")
+		version := spec
+		if !IsExactVersion(spec) {
+			v, err := resolveVersion(spec, installed)
+			if err != nil {
+				return nil, err
+			}
+			version = v.original
+		} else if !installed.contains(spec) {
+			return nil, fmt.Errorf("Go %s is not installed (run `golangver get %s`)", spec, spec)
+		}
+		if !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// Matrix runs command once per Go version concurrently with environment of the version (see Exec).
+// Every run uses version-specific GOCACHE, output of every run is prefixed by version.
+// Results are returned in order of versions, error is returned if some runs are failed.
+func (m *Manager) Matrix(opts MatrixOpts, args []string) ([]MatrixRun, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("command is not provided")
+	}
+	versions, err := m.ResolveInstalledVersions(opts.Versions)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("versions are not provided")
+	}
+	jobs := opts.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	runs := make([]MatrixRun, len(versions))
+	var (
+		outMu sync.Mutex
+		wg    sync.WaitGroup
+	)
+	sem := make(chan struct{}, jobs)
+	for i, version := range versions {
+		i, version := i, version
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			out := &prefixWriter{mu: &outMu, w: m.Stdout, prefix: "[" + version + "] "}
+			runs[i] = m.runVersion(version, args, out)
+			out.Flush()
+		}()
+	}
+	wg.Wait()
+
+	var failed int
+	for _, r := range runs {
+		if !r.Passed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return runs, fmt.Errorf("%d of %d runs failed", failed, len(runs))
+	}
+	return runs, nil
+}
+
+// runVersion runs command with environment of version and version-specific GOCACHE.
+func (m *Manager) runVersion(version string, args []string, out io.Writer) MatrixRun {
+	run := MatrixRun{Version: version, ExitCode: -1}
+	env, err := m.Env("", version)
+	if err != nil {
+		run.Err = err
+		return run
+	}
+	// parallel runs must not share build cache
+	env.setVar("GOCACHE", m.isolationDir(version, "GOCACHE"))
+	cmd, err := m.envCommand(env, args)
+	if err != nil {
+		run.Err = err
+		return run
+	}
+
+	var output bytes.Buffer
+	cmd.Stdout = io.MultiWriter(out, &output)
+	cmd.Stderr = cmd.Stdout
+	start := time.Now()
+	run.Err = m.runCommand(cmd)
+	run.Duration = time.Since(start)
+	run.Output = output.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case run.Err == nil:
+		run.ExitCode = 0
+	case errors.As(run.Err, &exitErr):
+		run.ExitCode = exitErr.ExitCode()
+	}
+	return run
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteMatrixJUnit writes JUnit XML report of runs: test case per Go version.
+func WriteMatrixJUnit(w io.Writer, args []string, runs []MatrixRun, started time.Time) error {
+	command := strings.Join(args, " ")
+	suite := junitTestSuite{Name: command, Tests: len(runs), Timestamp: started.UTC().Format(time.RFC3339)}
+	var total time.Duration
+	for _, r := range runs {
+		total += r.Duration
+		c := junitTestCase{
+			ClassName: "golangver.matrix",
+			Name:      "go" + r.Version,
+			Time:      junitSeconds(r.Duration),
+			SystemOut: r.Output,
+		}
+		if !r.Passed() {
+			suite.Failures++
+			c.Failure = &junitFailure{Message: r.Err.Error(), Text: outputTail(r.Output, 50)}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	suite.Time = junitSeconds(total)
+
+	report := junitTestSuites{
+		Name:     "golangver matrix",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// outputTail returns last n lines of output.
+func outputTail(output string, n int) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+type matrixJSONReport struct {
+	Command []string        `json:"command"`
+	Started time.Time       `json:"started"`
+	Runs    []matrixJSONRun `json:"runs"`
+}
+
+type matrixJSONRun struct {
+	Version  string  `json:"version"`
+	Passed   bool    `json:"passed"`
+	ExitCode int     `json:"exit_code"`
+	Duration float64 `json:"duration_seconds"`
+	Error    string  `json:"error,omitempty"`
+	Output   string  `json:"output"`
+}
+
+// WriteMatrixJSON writes JSON report of runs.
+func WriteMatrixJSON(w io.Writer, args []string, runs []MatrixRun, started time.Time) error {
+	report := matrixJSONReport{Command: args, Started: started.UTC()}
+	for _, r := range runs {
+		jr := matrixJSONRun{
+			Version:  r.Version,
+			Passed:   r.Passed(),
+			ExitCode: r.ExitCode,
+			Duration: r.Duration.Seconds(),
+			Output:   r.Output,
+		}
+		if r.Err != nil {
+			jr.Error = r.Err.Error()
+		}
+		report.Runs = append(report.Runs, jr)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,168 @@
+package golang_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+// addSDKCommand creates executable in bin directory of fake SDK which prints GOCACHE and exits with code.
+func addSDKCommand(t *testing.T, env *golangtest.Env, version string, name string, code int) {
+	t.Helper()
+	script := "#!/bin/sh\necho \"GOCACHE=$GOCACHE\"\nexit " + strconv.Itoa(code) + "\n"
+	if err := os.WriteFile(filepath.Join(env.SDKPath(version), "bin", name), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newMatrixEnv returns fake environment with SDKs of 1.21.3 and 1.22.1, command check fails with 1.22.1.
+func newMatrixEnv(t *testing.T) (*golangtest.Env, *golang.Manager) {
+	t.Helper()
+	env, m := newTestEnv(t)
+	for v, code := range map[string]int{"1.21.3": 0, "1.22.1": 3} {
+		if err := env.AddSDK(v); err != nil {
+			t.Fatal(err)
+		}
+		addSDKCommand(t, env, v, "check", code)
+	}
+	return env, m
+}
+
+func TestResolveInstalledVersions(t *testing.T) {
+	env, m := newMatrixEnv(t)
+	if err := env.AddSDK("1.21.1"); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := m.ResolveInstalledVersions([]string{"1.21", "v1.22.1", "1.21.3", ">=1.22"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1.21.3", "1.22.1"}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("versions %q, want %q", versions, want)
+	}
+	if _, err := m.ResolveInstalledVersions([]string{"1.20.1"}); err == nil {
+		t.Error("error is expected for not installed version")
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	_, m := newMatrixEnv(t)
+	var stdout bytes.Buffer
+	m.Stdout = &stdout
+
+	runs, err := m.Matrix(golang.MatrixOpts{Versions: []string{"1.21.3", "1.22.1"}, Jobs: 2}, []string{"check"})
+	if err == nil || err.Error() != "1 of 2 runs failed" {
+		t.Fatalf("error = %v, failed run is expected", err)
+	}
+	if len(runs) != 2 || runs[0].Version != "1.21.3" || runs[1].Version != "1.22.1" {
+		t.Fatalf("runs %+v, runs in order of versions are expected", runs)
+	}
+	for _, r := range runs {
+		// every version uses its own build cache
+		goCache := filepath.Join(m.CacheDir, "env", "go"+r.Version, "gocache")
+		if r.Output != "GOCACHE="+goCache+"\n" {
+			t.Errorf("%s: output %q, want GOCACHE=%s", r.Version, r.Output, goCache)
+		}
+		if _, err := os.Stat(goCache); err != nil {
+			t.Error(err)
+		}
+		requireContains(t, stdout.String(), "["+r.Version+"] GOCACHE="+goCache+"\n")
+	}
+	if !runs[0].Passed() || runs[0].ExitCode != 0 {
+		t.Errorf("1.21.3 run %+v, success is expected", runs[0])
+	}
+	if runs[1].Passed() || runs[1].ExitCode != 3 {
+		t.Errorf("1.22.1 run %+v, exit code 3 is expected", runs[1])
+	}
+
+	if _, err := m.Matrix(golang.MatrixOpts{Versions: []string{"1.21.3"}}, []string{"check"}); err != nil {
+		t.Errorf("passed run: %v", err)
+	}
+	if _, err := m.Matrix(golang.MatrixOpts{Versions: []string{"1.21.3"}}, nil); err == nil {
+		t.Error("error is expected without command")
+	}
+}
+
+func TestMatrixReports(t *testing.T) {
+	_, m := newMatrixEnv(t)
+	runs, _ := m.Matrix(golang.MatrixOpts{Versions: []string{"1.21.3", "1.22.1"}, Jobs: 1}, []string{"check", "-v"})
+	if len(runs) != 2 {
+		t.Fatalf("runs %+v", runs)
+	}
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var junit bytes.Buffer
+	if err := golang.WriteMatrixJUnit(&junit, []string{"check", "-v"}, runs, started); err != nil {
+		t.Fatal(err)
+	}
+	var suites struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name      string `xml:"name,attr"`
+			Timestamp string `xml:"timestamp,attr"`
+			Cases     []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Text    string `xml:",chardata"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(junit.Bytes(), &suites); err != nil {
+		t.Fatalf("%v\n%s", err, junit.String())
+	}
+	if suites.Tests != 2 || suites.Failures != 1 || len(suites.Suites) != 1 {
+		t.Fatalf("JUnit report:\n%s", junit.String())
+	}
+	suite := suites.Suites[0]
+	if suite.Name != "check -v" || suite.Timestamp != "2024-01-02T03:04:05Z" || len(suite.Cases) != 2 {
+		t.Fatalf("JUnit report:\n%s", junit.String())
+	}
+	if suite.Cases[0].Name != "go1.21.3" || suite.Cases[0].Failure != nil {
+		t.Errorf("test case %+v, passed go1.21.3 is expected", suite.Cases[0])
+	}
+	if c := suite.Cases[1]; c.Name != "go1.22.1" || c.Failure == nil || !strings.HasSuffix(c.Failure.Message, "exit status 3") ||
+		!strings.Contains(c.Failure.Text, "GOCACHE=") {
+		t.Errorf("test case %+v, failed go1.22.1 is expected", c)
+	}
+
+	var b bytes.Buffer
+	if err := golang.WriteMatrixJSON(&b, []string{"check", "-v"}, runs, started); err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		Command []string
+		Started time.Time
+		Runs    []struct {
+			Version  string
+			Passed   bool
+			ExitCode int `json:"exit_code"`
+			Error    string
+			Output   string
+		}
+	}
+	if err := json.Unmarshal(b.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report.Command, []string{"check", "-v"}) || !report.Started.Equal(started) || len(report.Runs) != 2 {
+		t.Fatalf("JSON report:\n%s", b.String())
+	}
+	if r := report.Runs[0]; r.Version != "1.21.3" || !r.Passed || r.ExitCode != 0 || r.Error != "" {
+		t.Errorf("run %+v, passed 1.21.3 is expected", r)
+	}
+	if r := report.Runs[1]; r.Version != "1.22.1" || r.Passed || r.ExitCode != 3 || !strings.HasSuffix(r.Error, "exit status 3") || r.Output == "" {
+		t.Errorf("run %+v, failed 1.22.1 is expected", r)
+	}
+}
@@ -0,0 +1,243 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// PolicyFile is name of team policy file in project root.
+const PolicyFile = ".golangver-policy.json"
+
+// Policy modes.
+const (
+	// PolicyEnforce refuses to use and install Go versions violating policy.
+	PolicyEnforce = "enforce"
+	// PolicyWarn only warns about Go versions violating policy.
+	PolicyWarn = "warn"
+)
+
+// Policy declares Go versions allowed in team, example:
+//
+//	{
+//	  "allowed": ["1.21", "1.22"],
+//	  "min_patch": {"1.21": "1.21.8", "1.22": "1.22.1"},
+//	  "banned": ["1.22.2", "<1.20"],
+//	  "mode": "enforce"
+//	}
+type Policy struct {
+	// Allowed are allowed Go major releases ("1.22") or constraints (">=1.21"), any release is allowed if empty.
+	Allowed []string `json:"allowed,omitempty"`
+	// MinPatch maps major release to the minimal allowed patch release (e.g. with security fix).
+	MinPatch map[string]string `json:"min_patch,omitempty"`
+	// Banned are banned versions ("1.22.2"), major releases ("1.19") or constraints ("<1.20").
+	// Major releases and constraints match stable releases only, pre-release ("1.23rc1") is banned by exact version.
+	Banned []string `json:"banned,omitempty"`
+	// Mode is PolicyEnforce (default) or PolicyWarn.
+	Mode string `json:"mode,omitempty"`
+
+	// Source is path or URL policy is loaded from.
+	Source string `json:"-"`
+}
+
+// Enforced reports whether violations of policy are errors.
+func (p *Policy) Enforced() bool {
+	return p.Mode != PolicyWarn
+}
+
+func (p *Policy) validate() error {
+	switch p.Mode {
+	case "", PolicyEnforce, PolicyWarn:
+	default:
+		return fmt.Errorf("unknown mode %q (supported: %s, %s)", p.Mode, PolicyEnforce, PolicyWarn)
+	}
+	for _, spec := range append(append([]string(nil), p.Allowed...), p.Banned...) {
+		if _, err := versionMatcher(spec); err != nil {
+			return err
+		}
+	}
+	for minor, min := range p.MinPatch {
+		minorInfo, err := parseVersionInfo(minor)
+		if err != nil {
+			return err
+		}
+		minInfo, err := parseVersionInfo(min)
+		if err != nil {
+			return err
+		}
+		if majorMinor(minorInfo) != minor || majorMinor(minInfo) != minor {
+			return fmt.Errorf("min_patch %q: %s is not patch release of %s", minor, min, minor)
+		}
+	}
+	return nil
+}
+
+// Violations returns reasons why Go version violates policy (empty if it's allowed).
+// Major release (like "1.21" of go directive) is checked against allowed releases only,
+// pre-release is checked against banned versions but not against banned major releases and constraints.
+func (p *Policy) Violations(version string) []string {
+	v, err := parseVersionInfo(strings.TrimPrefix(version, "go"))
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var violations []string
+	if len(p.Allowed) > 0 {
+		allowed := false
+		for _, spec := range p.Allowed {
+			if match, err := versionMatcher(spec); err == nil && match(minorVersion(v)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, fmt.Sprintf("Go %s is not in allowed releases (%s)",
+				majorMinor(v), strings.Join(p.Allowed, ", ")))
+		}
+	}
+	if !IsExactVersion(version) {
+		return violations
+	}
+
+	if min, ok := p.MinPatch[majorMinor(v)]; ok {
+		if minInfo, err := parseVersionInfo(min); err == nil && v.semver.LessThan(*minInfo.semver) {
+			violations = append(violations, fmt.Sprintf("minimal allowed patch release is %s", min))
+		}
+	}
+	for _, spec := range p.Banned {
+		if spec == version {
+			violations = append(violations, fmt.Sprintf("Go %s is banned", version))
+			continue
+		}
+		if match, err := versionMatcher(spec); err == nil && v.betaSuffix == "" && match(v.semver) {
+			violations = append(violations, fmt.Sprintf("Go %s is banned (%s)", version, spec))
+		}
+	}
+	return violations
+}
+
+// minorVersion returns the first release of major release of v (1.21.5 -> 1.21.0).
+func minorVersion(v *versionInfo) *semver.Version {
+	return &semver.Version{Major: v.semver.Major, Minor: v.semver.Minor}
+}
+
+// Policy returns team policy (nil if it isn't configured and project doesn't have policy file).
+// Policy is loaded from PolicySource (path or URL) or from policy file in project root.
+// Policy fetched by URL is cached: the cached copy is used if policy fetch fails.
+func (m *Manager) Policy() (*Policy, error) {
+	if m.policyLoaded {
+		return m.policy, nil
+	}
+	var (
+		b      []byte
+		source = m.PolicySource
+		err    error
+	)
+	switch {
+	case source == "":
+		source = m.projectPath(PolicyFile)
+		if b, err = os.ReadFile(source); os.IsNotExist(err) {
+			m.Logger.Debug("policy file is not found", "file", source)
+			m.policyLoaded = true
+			return nil, nil
+		}
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		b, err = m.fetchPolicy(source)
+	default:
+		b, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("policy %s read is failed: %w", source, err)
+	}
+
+	p := &Policy{Source: source}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("policy %s parsing is failed: %w", source, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("policy %s is invalid: %w", source, err)
+	}
+	m.Logger.Debug("policy is loaded", "source", source, "mode", p.Mode)
+	m.policy, m.policyLoaded = p, true
+	return p, nil
+}
+
+// fetchPolicy downloads policy file and keeps its copy in cache (per URL).
+func (m *Manager) fetchPolicy(url string) ([]byte, error) {
+	cached := m.urlCacheFile("policy.json", url)
+	b, err := m.httpGet(url)
+	if err != nil {
+		cachedBody, cacheErr := os.ReadFile(cached)
+		if cacheErr != nil {
+			return nil, err
+		}
+		m.Logger.Warn("policy fetch is failed, cached policy is used", "url", url, "err", err)
+		return cachedBody, nil
+	}
+	if err := os.MkdirAll(m.CacheDir, 0755); err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(cached, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (m *Manager) httpGet(url string) ([]byte, error) {
+	return m.httpGetContext(context.Background(), url)
+}
+
+func (m *Manager) httpGetContext(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	m.Logger.Debug("http request", "method", req.Method, "url", url)
+	resp, err := m.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	m.Logger.Debug("http response", "url", url, "status", resp.Status)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// checkPolicy returns *PolicyError if version violates enforced policy,
+// violations of not enforced policy are written to Stderr as warnings.
+func (m *Manager) checkPolicy(version string) error {
+	p, err := m.Policy()
+	if err != nil || p == nil {
+		return err
+	}
+	violations := p.Violations(version)
+	if len(violations) == 0 {
+		return nil
+	}
+	if p.Enforced() {
+		return &PolicyError{Version: version, Source: p.Source, Violations: violations}
+	}
+	for _, v := range violations {
+		fmt.Fprintf(m.Stderr, "WARNING: Go %s violates policy %s: %s\n", version, p.Source, v)
+	}
+	return nil
+}
+
+// policyStatus returns policy status of version for List: "allowed" or violations
+// (empty if there is no policy).
+func policyStatus(p *Policy, version string) string {
+	if p == nil {
+		return ""
+	}
+	if violations := p.Violations(version); len(violations) > 0 {
+		return "not allowed: " + strings.Join(violations, "; ")
+	}
+	return "allowed"
+}
@@ -0,0 +1,82 @@
+package golang_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+func TestRemotePolicyCacheIsKeyedByURL(t *testing.T) {
+	env, err := golangtest.NewEnv(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/a.json":
+			w.Write([]byte(`{"banned": ["1.21.0"]}`))
+		case "/b.json":
+			w.Write([]byte(`{"banned": ["1.22.0"]}`))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+	// policy is loaded once by Manager, so every load uses new one
+	loadPolicy := func(url string) (*golang.Policy, error) {
+		m := env.Manager()
+		m.HTTPClient = srv.Client()
+		m.PolicySource = url
+		return m.Policy()
+	}
+	for _, name := range []string{"a.json", "b.json"} {
+		if _, err := loadPolicy(srv.URL + "/" + name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	srv.Close()
+	for name, banned := range map[string]string{"a.json": "1.21.0", "b.json": "1.22.0"} {
+		p, err := loadPolicy(srv.URL + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p.Banned) != 1 || p.Banned[0] != banned {
+			t.Errorf("cached policy of %s bans %q, want %s", name, p.Banned, banned)
+		}
+	}
+	if _, err := loadPolicy(srv.URL + "/c.json"); err == nil {
+		t.Error("error is expected for unavailable policy without cache")
+	}
+}
+
+func TestPolicyViolations(t *testing.T) {
+	p := &golang.Policy{
+		Allowed:  []string{">=1.19"},
+		MinPatch: map[string]string{"1.21": "1.21.8"},
+		Banned:   []string{"1.22.2", "<1.20", "1.23", "1.24rc1"},
+	}
+	tests := map[string]int{
+		"1.22.1":  0,
+		"1.22.2":  1,
+		"1.21.3":  1,
+		"1.21.8":  0,
+		"1.19.13": 1,
+		"1.18.10": 2,
+		"1.23.4":  1,
+		"1.21":    0,
+		"1.18":    1,
+		// banned major releases and constraints don't match pre-releases
+		"1.23rc1": 0,
+		"1.19rc2": 0,
+		"1.24rc1": 1,
+	}
+	for version, want := range tests {
+		if got := p.Violations(version); len(got) != want {
+			t.Errorf("%s: violations %q, want %d", version, got, want)
+		}
+	}
+}
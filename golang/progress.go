@@ -0,0 +1,96 @@
+package golang
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	progressBarWidth = 30
+	// progressLineInterval is interval between progress lines if output isn't terminal.
+	progressLineInterval = 5 * time.Second
+)
+
+// progressWriter counts written bytes and reports download progress to out:
+// as progress bar if out is terminal, otherwise as periodic lines.
+type progressWriter struct {
+	out     io.Writer
+	name    string
+	total   int64
+	current int64
+	tty     bool
+	last    time.Time
+}
+
+func newProgressWriter(out io.Writer, name string, offset int64, total int64) *progressWriter {
+	return &progressWriter{
+		out:     out,
+		name:    name,
+		total:   total,
+		current: offset,
+		tty:     isTerminal(out),
+		last:    time.Now(),
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	p.current += int64(len(b))
+	now := time.Now()
+	switch {
+	case p.tty && now.Sub(p.last) >= 100*time.Millisecond:
+		p.last = now
+		fmt.Fprint(p.out, "\r"+p.bar())
+	case !p.tty && now.Sub(p.last) >= progressLineInterval:
+		p.last = now
+		fmt.Fprintln(p.out, p.line())
+	}
+	return len(b), nil
+}
+
+// Done prints final progress.
+func (p *progressWriter) Done() {
+	if p.tty {
+		fmt.Fprintln(p.out, "\r"+p.bar())
+		return
+	}
+	fmt.Fprintln(p.out, p.line())
+}
+
+func (p *progressWriter) percent() float64 {
+	if p.total <= 0 {
+		return 0
+	}
+	return float64(p.current) * 100 / float64(p.total)
+}
+
+func (p *progressWriter) bar() string {
+	filled := int(p.percent() * progressBarWidth / 100)
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	return fmt.Sprintf("%s [%s] %5.1f%% %s / %s", p.name, bar, p.percent(), formatMB(p.current), formatMB(p.total))
+}
+
+func (p *progressWriter) line() string {
+	return fmt.Sprintf("%s: downloaded %.1f%% (%s / %s)", p.name, p.percent(), formatMB(p.current), formatMB(p.total))
+}
+
+func formatMB(n int64) string {
+	return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+}
@@ -0,0 +1,23 @@
+package golang
+
+// Prompter asks user for confirmations.
+type Prompter interface {
+	// Confirm asks yes/no question, defaultYes is answer used on empty input.
+	Confirm(question string, defaultYes bool) (bool, error)
+}
+
+// PrompterFunc is adapter of function to Prompter (e.g. uitools.InputYesNo).
+type PrompterFunc func(question string, defaultYes bool) (bool, error)
+
+// Confirm calls f(question, defaultYes).
+func (f PrompterFunc) Confirm(question string, defaultYes bool) (bool, error) {
+	return f(question, defaultYes)
+}
+
+// DefaultAnswers is non-interactive Prompter which always answers by default.
+type DefaultAnswers struct{}
+
+// Confirm returns defaultYes.
+func (DefaultAnswers) Confirm(_ string, defaultYes bool) (bool, error) {
+	return defaultYes, nil
+}
@@ -0,0 +1,175 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// release is Go release from releases index.
+type release struct {
+	Version string        `json:"version"`
+	Stable  bool          `json:"stable"`
+	Files   []releaseFile `json:"files"`
+}
+
+// releaseFile is Go release file from releases index.
+type releaseFile struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	Sha256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"` // "archive", "installer", "source"
+}
+
+// releaseIndexCache is name of file in CacheDir with the last releases index fetched from ReleaseIndexURL
+// (see releaseIndexFile).
+const releaseIndexCache = "releases.json"
+
+// defaultIndexTTL is time cached releases index is used without fetch.
+const defaultIndexTTL = time.Hour
+
+// releaseIndex returns Go releases index: cached one if it's fresher than IndexTTL (and RefreshIndex isn't set),
+// fetched one otherwise. Stale cached index is used with warning if fetch fails (e.g. offline).
+func (m *Manager) releaseIndex() ([]release, error) {
+	releases, _, err := m.loadReleaseIndex()
+	return releases, err
+}
+
+// loadReleaseIndex returns releases index (see releaseIndex) and reports whether it's fetched.
+func (m *Manager) loadReleaseIndex() ([]release, bool, error) {
+	file := m.releaseIndexFile()
+	var cachedAt time.Time
+	if fi, err := os.Stat(file); err == nil {
+		cachedAt = fi.ModTime()
+	}
+	if !cachedAt.IsZero() && !m.RefreshIndex && time.Since(cachedAt) < m.IndexTTL {
+		releases, err := m.cachedReleaseIndex()
+		if err == nil && releases != nil {
+			m.Logger.Debug("cached releases index is used", "file", file, "age", time.Since(cachedAt).Round(time.Second))
+			return releases, false, nil
+		}
+		m.Logger.Debug("cached releases index read is failed", "file", file, "err", err)
+	}
+
+	releases, fetchErr := m.fetchReleaseIndex()
+	if fetchErr == nil {
+		return releases, true, nil
+	}
+	if cachedAt.IsZero() {
+		return nil, false, fetchErr
+	}
+	releases, err := m.cachedReleaseIndex()
+	if err != nil || releases == nil {
+		return nil, false, fetchErr
+	}
+	fmt.Fprintf(m.Stderr, "WARNING: %v, cached releases index of %s is used\n", fetchErr, cachedAt.Format("2006-01-02 15:04"))
+	return releases, false, nil
+}
+
+// fetchReleaseIndex fetches Go releases index, fetched index is saved to cache (see cachedReleaseIndex).
+func (m *Manager) fetchReleaseIndex() ([]release, error) {
+	releaseIndexURL := m.ReleaseIndexURL
+	m.Logger.Debug("http request", "method", http.MethodGet, "url", releaseIndexURL)
+	resp, err := m.HTTPClient.Get(releaseIndexURL)
+	if err != nil {
+		return nil, fmt.Errorf("releases index fetch is failed: %w", err)
+	}
+	defer resp.Body.Close()
+	m.Logger.Debug("http response", "url", releaseIndexURL, "status", resp.Status)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("releases index fetch is failed: %s %s", releaseIndexURL, resp.Status)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("releases index fetch is failed: %w", err)
+	}
+	var releases []release
+	if err := json.Unmarshal(b, &releases); err != nil {
+		return nil, fmt.Errorf("releases index decoding is failed: %w", err)
+	}
+	if err := m.saveReleaseIndex(b); err != nil {
+		m.Logger.Debug("releases index caching is failed", "err", err)
+	}
+	return releases, nil
+}
+
+func (m *Manager) saveReleaseIndex(b []byte) error {
+	if err := os.MkdirAll(m.CacheDir, 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(m.releaseIndexFile(), b)
+}
+
+// releaseIndexFile returns cache file of releases index fetched from ReleaseIndexURL.
+func (m *Manager) releaseIndexFile() string {
+	return m.urlCacheFile(releaseIndexCache, m.ReleaseIndexURL)
+}
+
+// cachedReleaseIndex returns releases index saved by the last fetch (nil if it isn't cached).
+func (m *Manager) cachedReleaseIndex() ([]release, error) {
+	file := m.releaseIndexFile()
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var releases []release
+	if err := json.Unmarshal(b, &releases); err != nil {
+		return nil, fmt.Errorf("%s parsing is failed: %w", file, err)
+	}
+	return releases, nil
+}
+
+// findArchive returns archive file of Go version for goos/goarch.
+func findArchive(releases []release, version string, goos string, goarch string) (*releaseFile, error) {
+	for _, r := range releases {
+		if r.Version != "go"+version {
+			continue
+		}
+		for i := range r.Files {
+			f := &r.Files[i]
+			if f.Kind == "archive" && f.OS == goos && f.Arch == goarch {
+				return f, nil
+			}
+		}
+		return nil, fmt.Errorf("archive of Go %s for %s/%s is not found", version, goos, goarch)
+	}
+	return nil, fmt.Errorf("Go %s is not found in releases index", version)
+}
+
+// platformArchive returns archive file of Go version for goos/goarch.
+// Releases index is fetched if cached one doesn't contain version (it can be released after index is cached).
+func (m *Manager) platformArchive(version string, goos string, goarch string) (*releaseFile, error) {
+	releases, fetched, err := m.loadReleaseIndex()
+	if err != nil {
+		return nil, err
+	}
+	if !fetched && !hasRelease(releases, version) {
+		m.Logger.Debug("cached releases index doesn't contain version", "version", version)
+		if fresh, err := m.fetchReleaseIndex(); err == nil {
+			releases = fresh
+		} else {
+			m.Logger.Debug("releases index fetch is failed", "err", err)
+		}
+	}
+	return findArchive(releases, version, goos, goarch)
+}
+
+// hasRelease reports whether releases index contains version.
+func hasRelease(releases []release, version string) bool {
+	for _, r := range releases {
+		if r.Version == "go"+version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package golang_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+// resolveStable resolves "stable" alias or fails test.
+func resolveStable(t *testing.T, m *golang.Manager) string {
+	t.Helper()
+	versions, err := m.ResolveVersions([]string{"stable"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return versions[0]
+}
+
+func TestReleaseIndexCacheIsKeyedByURL(t *testing.T) {
+	_, m := newTestEnv(t, "1.21.3")
+	m.IndexTTL = time.Hour
+	if v := resolveStable(t, m); v != "1.21.3" {
+		t.Fatalf("stable = %s, want 1.21.3", v)
+	}
+
+	// fresh cache of other index isn't used
+	srv, err := golangtest.NewReleaseServer("1.22.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Configure(m)
+	if v := resolveStable(t, m); v != "1.22.1" {
+		t.Errorf("stable = %s, want 1.22.1 from configured index", v)
+	}
+	completed, err := m.CompleteVersions(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, v := range completed {
+		found[v] = true
+	}
+	if found["1.21.3"] || !found["1.22.1"] {
+		t.Errorf("completion %q must contain versions of configured index only", completed)
+	}
+
+	// stale cache is used only for the same index
+	srv.Close()
+	m.IndexTTL = 0
+	if v := resolveStable(t, m); v != "1.22.1" {
+		t.Errorf("stable = %s, want 1.22.1 from stale cache", v)
+	}
+	m.ReleaseIndexURL = srv.URL + "/?mode=json"
+	if _, err := m.ResolveVersions([]string{"stable"}); err == nil {
+		t.Error("error is expected for unavailable index without cache")
+	}
+}
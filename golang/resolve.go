@@ -0,0 +1,141 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+// ResolveVersions resolves version specs to exact Go versions.
+// Spec can be:
+//   - exact version: 1.17.6, 1.18beta2
+//   - alias: stable (or latest) is the newest stable release
+//   - major release: 1.17 is the newest patch release of 1.17 (=1.17 is the initial release go1.17)
+//   - constraint: >=1.17, <1.18, ~1.17 is the newest stable release matching constraint
+//
+// Remote versions list is fetched only if some spec isn't exact version.
+func (m *Manager) ResolveVersions(specs []string) ([]string, error) {
+	var remotes versionList
+	var versions []string
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		spec = strings.TrimPrefix(spec, "v")
+		version := spec
+		if !IsExactVersion(spec) {
+			if spec != "stable" && spec != "latest" {
+				// invalid spec is reported without fetch of remote versions
+				if _, err := versionMatcher(spec); err != nil {
+					return nil, err
+				}
+			}
+			if remotes == nil {
+				var err error
+				if remotes, err = m.remoteVersions(); err != nil {
+					return nil, err
+				}
+			}
+			v, err := resolveVersion(spec, remotes)
+			if err != nil {
+				return nil, err
+			}
+			version = v.original
+		}
+		if !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// IsExactVersion reports whether spec is exact version (1.17.6, 1.18beta2).
+func IsExactVersion(spec string) bool {
+	parts, suffix := versionParts(spec)
+	if len(parts) < 2 || len(parts) > 3 {
+		return false
+	}
+	prefix := strings.TrimSuffix(spec, suffix)
+	if prefix != joinVersionParts(parts) {
+		return false
+	}
+	return len(parts) == 3 || suffix != ""
+}
+
+func joinVersionParts(parts []int64) string {
+	s := make([]string, len(parts))
+	for i, p := range parts {
+		s[i] = fmt.Sprint(p)
+	}
+	return strings.Join(s, ".")
+}
+
+// resolveVersion returns the newest stable version matching spec.
+func resolveVersion(spec string, remotes versionList) (*versionInfo, error) {
+	switch spec {
+	case "stable", "latest":
+		if v := latestStable(remotes); v != nil {
+			return v, nil
+		}
+		return nil, fmt.Errorf("stable Go versions are not found")
+	}
+
+	match, err := versionMatcher(spec)
+	if err != nil {
+		return nil, err
+	}
+	var found *versionInfo
+	for i := range remotes {
+		v := &remotes[i]
+		if v.betaSuffix != "" || !match(v.semver) {
+			continue
+		}
+		if found == nil || found.semver.LessThan(*v.semver) {
+			found = v
+		}
+	}
+	if found == nil {
+		return nil, &VersionNotFoundError{Spec: spec}
+	}
+	return found, nil
+}
+
+// versionMatcher returns function matching versions by spec (1.17, >=1.17, ~1.17, etc).
+func versionMatcher(spec string) (func(v *semver.Version) bool, error) {
+	op := strings.TrimRight(spec[:len(spec)-len(strings.TrimLeft(spec, "<>=~^"))], " ")
+	rest := strings.TrimSpace(spec[len(op):])
+	parts, suffix := versionParts(rest)
+	if len(parts) == 0 || len(parts) > 3 || suffix != "" {
+		return nil, fmt.Errorf("unknown version spec %q", spec)
+	}
+	for len(parts) < 3 {
+		parts = append(parts, 0)
+	}
+	base := semver.Version{Major: parts[0], Minor: parts[1], Patch: parts[2]}
+	sameMinor := func(v *semver.Version) bool {
+		return v.Major == base.Major && v.Minor == base.Minor && !v.LessThan(base)
+	}
+
+	switch op {
+	case "":
+		if strings.Count(rest, ".") == 2 {
+			return func(v *semver.Version) bool { return v.Equal(base) }, nil
+		}
+		return sameMinor, nil
+	case "~", "~>":
+		return sameMinor, nil
+	case "^":
+		return func(v *semver.Version) bool { return v.Major == base.Major && !v.LessThan(base) }, nil
+	case "=", "==":
+		return func(v *semver.Version) bool { return v.Equal(base) }, nil
+	case ">=":
+		return func(v *semver.Version) bool { return !v.LessThan(base) }, nil
+	case ">":
+		return func(v *semver.Version) bool { return base.LessThan(*v) }, nil
+	case "<=":
+		return func(v *semver.Version) bool { return !base.LessThan(*v) }, nil
+	case "<":
+		return func(v *semver.Version) bool { return v.LessThan(base) }, nil
+	}
+	return nil, fmt.Errorf("unknown version spec operator %q", op)
+}
@@ -0,0 +1,43 @@
+package golang
+
+import (
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// Command is external command run by Runner.
+type Command struct {
+	Name string
+	Args []string
+	// Dir is working directory (current directory if empty).
+	Dir string
+	// Env is environment in "key=value" form (environment of current process if nil).
+	Env    []string
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+func (c *Command) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// Runner runs external commands.
+type Runner interface {
+	Run(cmd *Command) error
+}
+
+// ExecRunner runs commands with os/exec.
+type ExecRunner struct{}
+
+// Run runs command and waits for its completion.
+func (ExecRunner) Run(c *Command) error {
+	cmd := exec.Command(c.Name, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Env = c.Env
+	cmd.Stdin = c.Stdin
+	cmd.Stdout = c.Stdout
+	cmd.Stderr = c.Stderr
+	return cmd.Run()
+}
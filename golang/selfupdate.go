@@ -0,0 +1,270 @@
+package golang
+
+import (
+	"context"
+	"debug/buildinfo"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// SelfModule is module path of golangver, it's installed by `go install SelfModule@<version>`.
+const SelfModule = "github.com/nordicdyno/golangver"
+
+const defaultModuleProxy = "https://proxy.golang.org"
+
+// selfUpdateNoticeTimeout limits check of golangver releases for update notice,
+// so unavailable module proxy doesn't delay commands.
+const selfUpdateNoticeTimeout = 3 * time.Second
+
+// selfUpdateStamp is file in CacheDir with time of the last check of golangver releases.
+const selfUpdateStamp = "self-update.json"
+
+// SelfUpdateOpts controls SelfUpdate behaviour.
+type SelfUpdateOpts struct {
+	// Current is version of running golangver ("(devel)" or empty for local builds).
+	Current string
+	// Version is version to install, the latest release by default.
+	Version string
+	// Check only reports if update is available.
+	Check bool
+	// Force installs version even if it isn't newer than Current (or Current is unknown).
+	Force bool
+	// BinDir is directory golangver binary is installed to (GOBIN of `go install`).
+	BinDir string
+}
+
+// SelfUpdateResult is result of SelfUpdate.
+type SelfUpdateResult struct {
+	Current string
+	// Latest is the latest release (or requested version).
+	Latest string
+	// Available is set if Latest is newer than Current.
+	Available bool
+	// Releases are releases newer than Current up to Latest (newest first).
+	Releases []string
+	// ChangesURL is URL of changes between Current and Latest.
+	ChangesURL string
+	// Installed is version of installed binary (empty if nothing is installed).
+	Installed string
+	// File is installed binary.
+	File string
+}
+
+// SelfUpdate checks golangver releases in ModuleProxy and installs the latest one (or opts.Version)
+// by `go install` with current Go toolchain.
+// In check mode ErrUpgradeAvailable is returned together with result if newer release is available.
+func (m *Manager) SelfUpdate(opts SelfUpdateOpts) (*SelfUpdateResult, error) {
+	var (
+		latest string
+		err    error
+	)
+	if opts.Version == "" {
+		if latest, err = m.latestSelfRelease(); err != nil {
+			return nil, err
+		}
+		m.writeSelfUpdateStamp(latest)
+	} else if latest, err = m.selfRelease(opts.Version); err != nil {
+		return nil, err
+	}
+
+	res := &SelfUpdateResult{Current: opts.Current, Latest: latest, ChangesURL: selfChangesURL(opts.Current, latest)}
+	known := semver.IsValid(opts.Current)
+	res.Available = !known || semver.Compare(latest, opts.Current) > 0
+	versions, err := m.selfReleases()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if semver.Compare(v, latest) <= 0 && (!known || semver.Compare(v, opts.Current) > 0) {
+			res.Releases = append(res.Releases, v)
+		}
+	}
+
+	if opts.Check && res.Available {
+		return res, ErrUpgradeAvailable
+	}
+	if opts.Check || (!res.Available && !opts.Force) {
+		return res, nil
+	}
+	if !known && !opts.Force {
+		return nil, fmt.Errorf("golangver is built from local sources (version %q), use --force to install %s", opts.Current, latest)
+	}
+
+	fmt.Fprintf(m.Stdout, "Install %s@%s...\n", SelfModule, latest)
+	// project go.mod and go.work must not affect install
+	cmd := m.command(m.Stdout, "go", "install", SelfModule+"@"+latest)
+	cmd.Dir = opts.BinDir
+	env := setEnv(os.Environ(), "GOBIN", opts.BinDir)
+	if m.ModuleProxy != "" {
+		// otherwise GOPROXY of user (e.g. corporate proxy) is used
+		env = setEnv(env, "GOPROXY", m.moduleProxy())
+	}
+	cmd.Env = setEnv(env, "GOTOOLCHAIN", "local")
+	if err := m.runCommand(cmd); err != nil {
+		return nil, err
+	}
+
+	res.File = filepath.Join(opts.BinDir, "golangver")
+	if runtime.GOOS == "windows" {
+		res.File += ".exe"
+	}
+	res.Installed = latest
+	if info, err := buildinfo.ReadFile(res.File); err == nil {
+		res.Installed = info.Main.Version
+	} else {
+		m.Logger.Debug("build info read is failed", "file", res.File, "err", err)
+	}
+	return res, nil
+}
+
+// SelfUpdateNotice returns the latest golangver release if it's newer than current one (empty otherwise).
+// ModuleProxy is requested at most once per interval, the latest release is kept in CacheDir between checks.
+func (m *Manager) SelfUpdateNotice(current string, interval time.Duration) (string, error) {
+	if !semver.IsValid(current) {
+		return "", nil
+	}
+	stamp := m.readSelfUpdateStamp()
+	latest := stamp.Latest
+	if time.Since(stamp.Checked) >= interval {
+		ctx, cancel := context.WithTimeout(context.Background(), selfUpdateNoticeTimeout)
+		defer cancel()
+		var err error
+		if latest, err = m.selfVersionInfo(ctx, "@latest"); err != nil {
+			// failed check is not repeated until interval passes
+			m.writeSelfUpdateStamp(stamp.Latest)
+			return "", err
+		}
+		m.writeSelfUpdateStamp(latest)
+	}
+	if semver.IsValid(latest) && semver.Compare(latest, current) > 0 {
+		return latest, nil
+	}
+	return "", nil
+}
+
+type selfUpdateStampData struct {
+	Checked time.Time `json:"checked"`
+	Latest  string    `json:"latest"`
+}
+
+func (m *Manager) readSelfUpdateStamp() selfUpdateStampData {
+	var stamp selfUpdateStampData
+	b, err := os.ReadFile(filepath.Join(m.CacheDir, selfUpdateStamp))
+	if err == nil {
+		err = json.Unmarshal(b, &stamp)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		m.Logger.Debug("self-update stamp read is failed", "err", err)
+	}
+	return stamp
+}
+
+func (m *Manager) writeSelfUpdateStamp(latest string) {
+	b, err := json.Marshal(selfUpdateStampData{Checked: time.Now().UTC(), Latest: latest})
+	if err == nil {
+		err = os.MkdirAll(m.CacheDir, 0755)
+	}
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(m.CacheDir, selfUpdateStamp), append(b, '\n'))
+	}
+	if err != nil {
+		m.Logger.Debug("self-update stamp write is failed", "err", err)
+	}
+}
+
+// moduleProxy returns base URL of Go module proxy.
+func (m *Manager) moduleProxy() string {
+	if m.ModuleProxy == "" {
+		return defaultModuleProxy
+	}
+	return strings.TrimSuffix(m.ModuleProxy, "/")
+}
+
+// selfModuleURL returns URL of golangver module endpoint in module proxy ("@latest", "@v/list").
+func (m *Manager) selfModuleURL(endpoint string) (string, error) {
+	escaped, err := module.EscapePath(SelfModule)
+	if err != nil {
+		return "", err
+	}
+	return m.moduleProxy() + "/" + escaped + "/" + endpoint, nil
+}
+
+// latestSelfRelease returns the latest golangver release known to module proxy.
+func (m *Manager) latestSelfRelease() (string, error) {
+	return m.selfVersionInfo(context.Background(), "@latest")
+}
+
+// selfRelease returns canonical golangver version if it's known to module proxy.
+func (m *Manager) selfRelease(version string) (string, error) {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) {
+		return "", fmt.Errorf("invalid golangver version %s", version)
+	}
+	escaped, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", err
+	}
+	return m.selfVersionInfo(context.Background(), "@v/"+escaped+".info")
+}
+
+// selfVersionInfo returns version from golangver version info endpoint of module proxy.
+func (m *Manager) selfVersionInfo(ctx context.Context, endpoint string) (string, error) {
+	url, err := m.selfModuleURL(endpoint)
+	if err != nil {
+		return "", err
+	}
+	b, err := m.httpGetContext(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("golangver release check is failed: %w", err)
+	}
+	var info struct{ Version string }
+	if err := json.Unmarshal(b, &info); err != nil {
+		return "", fmt.Errorf("%s parsing is failed: %w", url, err)
+	}
+	if !semver.IsValid(info.Version) {
+		return "", fmt.Errorf("%s returned invalid version %q", url, info.Version)
+	}
+	return info.Version, nil
+}
+
+// selfReleases returns golangver releases known to module proxy (newest first, pre-releases are skipped).
+func (m *Manager) selfReleases() ([]string, error) {
+	url, err := m.selfModuleURL("@v/list")
+	if err != nil {
+		return nil, err
+	}
+	b, err := m.httpGet(url)
+	if err != nil {
+		return nil, fmt.Errorf("golangver releases list is failed: %w", err)
+	}
+	var versions []string
+	for _, v := range strings.Fields(string(b)) {
+		if semver.IsValid(v) && semver.Prerelease(v) == "" {
+			versions = append(versions, v)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.Compare(versions[i], versions[j]) > 0
+	})
+	return versions, nil
+}
+
+// selfChangesURL returns URL of changes between golangver versions.
+func selfChangesURL(current string, latest string) string {
+	base := "https://" + SelfModule
+	if semver.IsValid(current) && semver.Compare(latest, current) > 0 {
+		return base + "/compare/" + current + "..." + latest
+	}
+	return base + "/releases/tag/" + latest
+}
@@ -0,0 +1,202 @@
+package golang_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+// moduleProxy is module proxy serving golangver releases.
+type moduleProxy struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	latest   string
+	requests []string
+}
+
+func newModuleProxy(t *testing.T, latest string, versions ...string) *moduleProxy {
+	p := &moduleProxy{latest: latest}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.requests = append(p.requests, req.URL.Path)
+		switch req.URL.Path {
+		case "/github.com/nordicdyno/golangver/@latest":
+			w.Write([]byte(`{"Version":"` + p.latest + `","Time":"2024-01-01T00:00:00Z"}`))
+		case "/github.com/nordicdyno/golangver/@v/list":
+			for _, v := range versions {
+				w.Write([]byte(v + "\n"))
+			}
+		case "/github.com/nordicdyno/golangver/@v/v1.2.0.info":
+			w.Write([]byte(`{"Version":"v1.2.0","Time":"2023-06-01T00:00:00Z"}`))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *moduleProxy) count(path string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, r := range p.requests {
+		if r == path {
+			n++
+		}
+	}
+	return n
+}
+
+// hostRewriter sends all requests to server.
+type hostRewriter struct {
+	server *url.URL
+	next   http.RoundTripper
+}
+
+func (r hostRewriter) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = r.server.Scheme, r.server.Host
+	return r.next.RoundTrip(req)
+}
+
+// recordingRunner records commands and runs nothing.
+type recordingRunner struct {
+	mu   sync.Mutex
+	cmds []*golang.Command
+}
+
+func (r *recordingRunner) Run(cmd *golang.Command) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cmds = append(r.cmds, cmd)
+	return nil
+}
+
+func newSelfUpdateManager(t *testing.T, proxy *moduleProxy) *golang.Manager {
+	env, err := golangtest.NewEnv(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := env.Manager()
+	m.HTTPClient = proxy.Client()
+	m.ModuleProxy = proxy.URL
+	return m
+}
+
+func TestSelfUpdateCheck(t *testing.T) {
+	proxy := newModuleProxy(t, "v1.3.0", "v1.0.0", "v1.1.0", "v1.3.0", "v1.2.0", "v1.4.0-rc.1")
+	m := newSelfUpdateManager(t, proxy)
+
+	res, err := m.SelfUpdate(golang.SelfUpdateOpts{Current: "v1.1.0", Check: true})
+	if !errors.Is(err, golang.ErrUpgradeAvailable) {
+		t.Fatalf("error = %v, ErrUpgradeAvailable is expected", err)
+	}
+	if res.Latest != "v1.3.0" || !reflect.DeepEqual(res.Releases, []string{"v1.3.0", "v1.2.0"}) {
+		t.Errorf("latest %s, releases %q, want v1.3.0, [v1.3.0 v1.2.0]", res.Latest, res.Releases)
+	}
+	if proxy.count("/github.com/nordicdyno/golangver/@latest") != 1 || proxy.count("/github.com/nordicdyno/golangver/@v/list") != 1 {
+		t.Errorf("proxy requests %q", proxy.requests)
+	}
+
+	res, err = m.SelfUpdate(golang.SelfUpdateOpts{Current: "v1.3.0", Check: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Available {
+		t.Error("update of the latest release isn't expected")
+	}
+}
+
+func TestSelfUpdateInstall(t *testing.T) {
+	proxy := newModuleProxy(t, "v1.3.0", "v1.1.0", "v1.2.0", "v1.3.0")
+	t.Setenv("GOPROXY", "https://goproxy.corp.invalid")
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, configured := range []bool{true, false} {
+		m := newSelfUpdateManager(t, proxy)
+		wantProxy := proxy.URL
+		if !configured {
+			// default module proxy is requested by check, but `go install` uses GOPROXY of user
+			m.ModuleProxy = ""
+			m.HTTPClient = &http.Client{Transport: hostRewriter{server: proxyURL, next: proxy.Client().Transport}}
+			wantProxy = "https://goproxy.corp.invalid"
+		}
+		runner := &recordingRunner{}
+		m.Runner = runner
+		binDir := t.TempDir()
+
+		res, err := m.SelfUpdate(golang.SelfUpdateOpts{Current: "v1.1.0", Version: "1.2.0", BinDir: binDir})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Installed != "v1.2.0" || res.File != filepath.Join(binDir, "golangver") {
+			t.Errorf("installed %s to %s", res.Installed, res.File)
+		}
+		if len(runner.cmds) != 1 || runner.cmds[0].String() != "go install github.com/nordicdyno/golangver@v1.2.0" {
+			t.Fatalf("commands %v", runner.cmds)
+		}
+		env := map[string]string{}
+		for _, kv := range runner.cmds[0].Env {
+			name, value, _ := strings.Cut(kv, "=")
+			env[name] = value
+		}
+		if env["GOPROXY"] != wantProxy || env["GOBIN"] != binDir || env["GOTOOLCHAIN"] != "local" {
+			t.Errorf("configured proxy %v: GOPROXY=%s GOBIN=%s GOTOOLCHAIN=%s, want GOPROXY=%s",
+				configured, env["GOPROXY"], env["GOBIN"], env["GOTOOLCHAIN"], wantProxy)
+		}
+	}
+}
+
+func TestSelfUpdateNotice(t *testing.T) {
+	proxy := newModuleProxy(t, "v1.3.0")
+	m := newSelfUpdateManager(t, proxy)
+	const latestPath = "/github.com/nordicdyno/golangver/@latest"
+
+	latest, err := m.SelfUpdateNotice("v1.1.0", time.Hour)
+	if err != nil || latest != "v1.3.0" {
+		t.Fatalf("notice %q, %v, want v1.3.0", latest, err)
+	}
+	if _, err := os.Stat(filepath.Join(m.CacheDir, "self-update.json")); err != nil {
+		t.Fatal(err)
+	}
+	// the latest release is taken from stamp until interval passes
+	proxy.mu.Lock()
+	proxy.latest = "v1.4.0"
+	proxy.mu.Unlock()
+	if latest, err := m.SelfUpdateNotice("v1.1.0", time.Hour); err != nil || latest != "v1.3.0" {
+		t.Errorf("notice %q, %v, want v1.3.0", latest, err)
+	}
+	if n := proxy.count(latestPath); n != 1 {
+		t.Errorf("proxy is requested %d times, want once", n)
+	}
+	if latest, err := m.SelfUpdateNotice("v1.1.0", 0); err != nil || latest != "v1.4.0" {
+		t.Errorf("notice %q, %v, want v1.4.0", latest, err)
+	}
+	if latest, err := m.SelfUpdateNotice("v1.4.0", 0); err != nil || latest != "" {
+		t.Errorf("notice %q, %v, nothing is expected for the latest release", latest, err)
+	}
+	// local builds are never notified
+	if latest, err := m.SelfUpdateNotice("(devel)", 0); err != nil || latest != "" {
+		t.Errorf("notice %q, %v for local build", latest, err)
+	}
+	if n := proxy.count(latestPath); n != 3 {
+		t.Errorf("proxy is requested %d times, want 3", n)
+	}
+}
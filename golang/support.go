@@ -0,0 +1,77 @@
+package golang
+
+import "fmt"
+
+// supportedMajorReleases is number of the most recent major Go releases (1.N) which are supported,
+// see https://go.dev/doc/devel/release#policy.
+const supportedMajorReleases = 2
+
+// latestStable returns the newest stable (not beta or rc) version from the list.
+func latestStable(versions versionList) *versionInfo {
+	var latest *versionInfo
+	for i := range versions {
+		v := &versions[i]
+		if v.betaSuffix != "" {
+			continue
+		}
+		if latest == nil || latest.semver.LessThan(*v.semver) {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// isEOL reports whether major release of v is not supported anymore.
+func isEOL(v *versionInfo, latest *versionInfo) bool {
+	if v.semver.Major != latest.semver.Major {
+		return v.semver.Major < latest.semver.Major
+	}
+	return v.semver.Minor <= latest.semver.Minor-supportedMajorReleases
+}
+
+// newestPatch returns the newest stable patch release of v's major release.
+func newestPatch(v *versionInfo, versions versionList) *versionInfo {
+	var newest *versionInfo
+	for i := range versions {
+		r := &versions[i]
+		if r.betaSuffix != "" || r.semver.Major != v.semver.Major || r.semver.Minor != v.semver.Minor {
+			continue
+		}
+		if newest == nil || newest.semver.LessThan(*r.semver) {
+			newest = r
+		}
+	}
+	return newest
+}
+
+// versionStatus returns support status of version v:
+// "EOL", "pre-release", "superseded by <version>" or "supported".
+// Patch releases are issued for security and critical fixes only,
+// so superseded version misses security fixes.
+func versionStatus(v *versionInfo, remotes versionList, latest *versionInfo) string {
+	if isEOL(v, latest) {
+		return "EOL"
+	}
+	if v.betaSuffix != "" {
+		return "pre-release"
+	}
+	if newest := newestPatch(v, remotes); newest != nil && v.semver.LessThan(*newest.semver) {
+		return "superseded by " + newest.original
+	}
+	return "supported"
+}
+
+// currentStatusWarning returns warning if current version is EOL or misses security fixes (empty otherwise).
+func currentStatusWarning(current *versionInfo, remotes versionList, latest *versionInfo) string {
+	if isEOL(current, latest) {
+		return fmt.Sprintf("current Go %s is end-of-life, latest is %s", current.original, latest.original)
+	}
+	if current.betaSuffix != "" {
+		return ""
+	}
+	if newest := newestPatch(current, remotes); newest != nil && current.semver.LessThan(*newest.semver) {
+		return fmt.Sprintf("current Go %s misses security fixes of %s (run `golangver get %s`)",
+			current.original, newest.original, newest.original)
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SyncResult is result of Sync.
+type SyncResult struct {
+	Version string
+	// Found is number of detected version references.
+	Found int
+	// Diff is unified diff of proposed changes (empty if references are up to date).
+	Diff string
+	// Files are written files (empty if changes are declined).
+	Files []FileWrite
+}
+
+// Sync suggests to set Go version in Dockerfiles, CI configs and .tool-versions of project.
+// If version is empty, version of linkPath symlink target is used,
+// version spec (stable, 1.N, constraint) is resolved like by ResolveVersions.
+// Proposed changes are written to Stdout before confirmation.
+func (m *Manager) Sync(linkPath string, version string) (*SyncResult, error) {
+	if version == "" {
+		var err error
+		_, version, err = m.currentVersion(linkPath)
+		if err != nil {
+			return nil, err
+		}
+	} else if !IsExactVersion(version) {
+		versions, err := m.ResolveVersions([]string{version})
+		if err != nil {
+			return nil, err
+		}
+		version = versions[0]
+	}
+	if _, err := parseVersionInfo(version); err != nil {
+		return nil, err
+	}
+
+	refs, err := findVersionRefs(m.ProjectDir, versionRefPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("version references search is failed: %w", err)
+	}
+	res := &SyncResult{Version: version, Found: len(refs)}
+
+	type fileChange struct {
+		file     string
+		oldLines []string
+		newLines []string
+		// eol is true if file ends with new line
+		eol bool
+	}
+	var changes []*fileChange
+	byFile := map[string]*fileChange{}
+	for _, ref := range refs {
+		newValue := bumpVersion(ref.value, version)
+		if newValue == ref.value {
+			continue
+		}
+		ch, ok := byFile[ref.file]
+		if !ok {
+			b, err := os.ReadFile(ref.file)
+			if err != nil {
+				return nil, err
+			}
+			lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+			ch = &fileChange{
+				file:     ref.file,
+				oldLines: lines,
+				newLines: append([]string(nil), lines...),
+				eol:      strings.HasSuffix(string(b), "\n"),
+			}
+			byFile[ref.file] = ch
+			changes = append(changes, ch)
+		}
+		line := ch.newLines[ref.line-1]
+		ch.newLines[ref.line-1] = line[:ref.start] + newValue + line[ref.end:]
+	}
+
+	if len(changes) == 0 {
+		return res, nil
+	}
+
+	var diff strings.Builder
+	for _, ch := range changes {
+		diff.WriteString(unifiedDiff(ch.file, ch.oldLines, ch.newLines))
+	}
+	res.Diff = diff.String()
+
+	fmt.Fprintf(m.Stdout, "Go version references are detected, proposed changes for Go %s:\n\n", version)
+	fmt.Fprint(m.Stdout, res.Diff)
+	yes, err := m.Prompter.Confirm("\nDo you want to apply changes?", false)
+	if err != nil {
+		return res, err
+	}
+	if !yes {
+		return res, nil
+	}
+
+	for _, ch := range changes {
+		content := strings.Join(ch.newLines, "\n")
+		if ch.eol {
+			content += "\n"
+		}
+		if err := writeFileAtomic(ch.file, []byte(content)); err != nil {
+			return res, fmt.Errorf("write %s is failed: %w", ch.file, err)
+		}
+		res.Files = append(res.Files, FileWrite{File: ch.file})
+	}
+	return res, nil
+}
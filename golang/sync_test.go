@@ -0,0 +1,37 @@
+package golang_test
+
+import (
+	"testing"
+)
+
+func TestSyncResolvesSpec(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1", "1.22.0", "1.21.8")
+	m.Prompter = alwaysYes
+	if err := env.WriteProjectFile("Dockerfile", "FROM golang:1.21.3 AS build\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := m.Sync(env.GoBinLink, "stable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Version != "1.22.1" {
+		t.Errorf("version = %s, want 1.22.1", res.Version)
+	}
+	got, err := env.ReadProjectFile("Dockerfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "FROM golang:1.22.1 AS build\n"; got != want {
+		t.Errorf("Dockerfile = %q, want %q", got, want)
+	}
+}
+
+func TestSyncInvalidVersion(t *testing.T) {
+	env, m := newTestEnv(t)
+	for _, version := range []string{"x", "1.x", "go"} {
+		if _, err := m.Sync(env.GoBinLink, version); err == nil {
+			t.Errorf("Sync(%q) error is expected", version)
+		}
+	}
+}
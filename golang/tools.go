@@ -0,0 +1,145 @@
+package golang
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Tool is Go binary installed to GOPATH/bin (gopls, staticcheck, dlv, etc).
+type Tool struct {
+	Name string
+	File string
+	// GoVersion is Go version the binary is built with (without "go" prefix).
+	GoVersion string
+	// Path is main package path.
+	Path string
+	// Module and Version are path and version of main module ("(devel)" for local builds).
+	Module  string
+	Version string
+	// Outdated is set if the binary is built with other Go major release than current one.
+	Outdated bool
+}
+
+// Reinstallable reports whether tool can be installed again by `go install path@version`.
+func (t *Tool) Reinstallable() bool {
+	return t.Path != "" && t.Version != "" && t.Version != "(devel)"
+}
+
+// ToolsResult is result of Tools.
+type ToolsResult struct {
+	// GoVersion is current Go version (version of Go binary symlink target).
+	GoVersion string
+	Tools     []Tool
+}
+
+// Outdated returns tools built with other Go major release than current one.
+func (r *ToolsResult) Outdated() []Tool {
+	var tools []Tool
+	for _, t := range r.Tools {
+		if t.Outdated {
+			tools = append(tools, t)
+		}
+	}
+	return tools
+}
+
+// Tools returns Go binaries from GOPATH/bin with their build info.
+// golang.org/dl helper tools, symlinks and non-Go files are skipped.
+func (m *Manager) Tools(linkPath string) (*ToolsResult, error) {
+	_, current, err := m.currentVersion(linkPath)
+	if err != nil {
+		return nil, err
+	}
+	currentInfo, err := parseVersionInfo(current)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(m.binDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ToolsResult{GoVersion: current}, nil
+		}
+		return nil, err
+	}
+	res := &ToolsResult{GoVersion: current}
+	for _, e := range entries {
+		// symlinks are SDKs registered by golangver
+		if !e.Type().IsRegular() {
+			continue
+		}
+		file := filepath.Join(m.binDir(), e.Name())
+		info, err := buildinfo.ReadFile(file)
+		if err != nil {
+			m.Logger.Debug("build info read is failed", "file", file, "err", err)
+			continue
+		}
+		if strings.HasPrefix(info.Path, "golang.org/dl/go") {
+			continue
+		}
+
+		goVersion := strings.TrimPrefix(strings.Fields(info.GoVersion)[0], "go")
+		t := Tool{
+			Name:      e.Name(),
+			File:      file,
+			GoVersion: goVersion,
+			Path:      info.Path,
+			Module:    info.Main.Path,
+			Version:   info.Main.Version,
+		}
+		if v, err := parseVersionInfo(goVersion); err == nil {
+			t.Outdated = majorMinor(v) != majorMinor(currentInfo)
+		}
+		res.Tools = append(res.Tools, t)
+	}
+	sort.Slice(res.Tools, func(i, j int) bool { return res.Tools[i].Name < res.Tools[j].Name })
+	return res, nil
+}
+
+// ToolRebuild is result of tool rebuild.
+type ToolRebuild struct {
+	Tool Tool
+	Err  error
+}
+
+// RebuildTools installs tools again (`go install path@version`) with Go binary of linkPath symlink target,
+// toolchain switch is disabled (GOTOOLCHAIN=local). Failed rebuild doesn't stop others.
+func (m *Manager) RebuildTools(linkPath string, tools []Tool) ([]ToolRebuild, error) {
+	target, err := m.goBinCheckSymlink(linkPath)
+	if err != nil {
+		return nil, fmt.Errorf("check symlink %s is failed: %w", linkPath, err)
+	}
+	if target == "" {
+		return nil, fmt.Errorf("symlink %s not found", linkPath)
+	}
+
+	var (
+		results []ToolRebuild
+		failed  int
+	)
+	for _, t := range tools {
+		r := ToolRebuild{Tool: t}
+		if t.Reinstallable() {
+			fmt.Fprintf(m.Stdout, "Install %s@%s...\n", t.Path, t.Version)
+			// project go.mod and go.work must not affect install
+			cmd := m.command(m.Stdout, target, "install", t.Path+"@"+t.Version)
+			cmd.Dir = m.binDir()
+			cmd.Env = setEnv(setEnv(os.Environ(), "GOBIN", m.binDir()), "GOTOOLCHAIN", "local")
+			r.Err = m.runCommand(cmd)
+		} else {
+			r.Err = fmt.Errorf("%s is built from local sources", t.Name)
+		}
+		if r.Err != nil {
+			failed++
+		}
+		results = append(results, r)
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d tools rebuild failed", failed, len(tools))
+	}
+	return results, nil
+}
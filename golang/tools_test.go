@@ -0,0 +1,124 @@
+package golang_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+// buildTool builds main package pkg of module mod to file with Go running the test.
+func buildTool(t *testing.T, mod string, pkg string, file string) {
+	t.Helper()
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not found:", err)
+	}
+	dir := t.TempDir()
+	src := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(pkg, mod), "/")))
+	if err := os.MkdirAll(src, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+mod+"\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(goBin, "build", "-buildvcs=false", "-o", file, pkg)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOWORK=off", "GOFLAGS=")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%s: %v\n%s", cmd, err, out)
+	}
+}
+
+func TestTools(t *testing.T) {
+	env, m := newTestEnv(t)
+	// fake SDK is older than Go running the test
+	if err := env.AddSDK("1.10.8"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Use("1.10.8"); err != nil {
+		t.Fatal(err)
+	}
+	buildTool(t, "example.com/tool", "example.com/tool/cmd/tool", filepath.Join(env.BinDir(), "tool"))
+	buildTool(t, "golang.org/dl", "golang.org/dl/go1.21.3", filepath.Join(env.BinDir(), "go1.21.3"))
+	if err := os.WriteFile(filepath.Join(env.BinDir(), "script"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(env.BinDir(), "tool"), filepath.Join(env.BinDir(), "tool-link")); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := m.Tools(env.GoBinLink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GoVersion != "1.10.8" {
+		t.Errorf("current version %s, want 1.10.8", res.GoVersion)
+	}
+	// helper tools, scripts of fake environment and symlinks are skipped
+	if len(res.Tools) != 1 {
+		t.Fatalf("tools %+v, only tool is expected", res.Tools)
+	}
+	tool := res.Tools[0]
+	if tool.Name != "tool" || tool.Path != "example.com/tool/cmd/tool" || tool.Module != "example.com/tool" ||
+		!strings.HasPrefix(runtime.Version(), "go"+tool.GoVersion) {
+		t.Errorf("tool %+v", tool)
+	}
+	if !tool.Outdated || len(res.Outdated()) != 1 {
+		t.Errorf("tool %+v, outdated is expected", tool)
+	}
+	if tool.Reinstallable() {
+		t.Errorf("local build %+v isn't expected to be reinstallable", tool)
+	}
+}
+
+func TestRebuildTools(t *testing.T) {
+	env, m := newTestEnv(t)
+	if err := env.AddSDK("1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Use("1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	runner := &recordingRunner{}
+	m.Runner = runner
+
+	tools := []golang.Tool{
+		{Name: "gopls", Path: "golang.org/x/tools/gopls", Module: "golang.org/x/tools/gopls", Version: "v0.14.2"},
+		{Name: "local", Path: "example.com/local", Module: "example.com/local", Version: "(devel)"},
+	}
+	results, err := m.RebuildTools(env.GoBinLink, tools)
+	if err == nil || err.Error() != "1 of 2 tools rebuild failed" {
+		t.Errorf("error = %v, failed rebuild of local build is expected", err)
+	}
+	if len(results) != 2 || results[0].Err != nil || results[1].Err == nil {
+		t.Fatalf("results %+v", results)
+	}
+	requireContains(t, results[1].Err.Error(), "built from local sources")
+
+	if len(runner.cmds) != 1 {
+		t.Fatalf("commands %v, only install of gopls is expected", runner.cmds)
+	}
+	cmd := runner.cmds[0]
+	if want := filepath.Join(env.SDKPath("1.22.1"), "bin", "go") + " install golang.org/x/tools/gopls@v0.14.2"; cmd.String() != want {
+		t.Errorf("command %s, want %s", cmd, want)
+	}
+	if cmd.Dir != env.BinDir() {
+		t.Errorf("command is run in %s, want %s", cmd.Dir, env.BinDir())
+	}
+	vars := map[string]string{}
+	for _, kv := range cmd.Env {
+		name, value, _ := strings.Cut(kv, "=")
+		vars[name] = value
+	}
+	if vars["GOBIN"] != env.BinDir() || vars["GOTOOLCHAIN"] != "local" {
+		t.Errorf("GOBIN=%s GOTOOLCHAIN=%s, want GOBIN=%s GOTOOLCHAIN=local", vars["GOBIN"], vars["GOTOOLCHAIN"], env.BinDir())
+	}
+}
@@ -0,0 +1,96 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// majorReleaseRe matches Go major release accepted by Upgrade (1.22).
+var majorReleaseRe = regexp.MustCompile(`^1\.\d+$`)
+
+// UpgradeOpts controls Upgrade behaviour.
+type UpgradeOpts struct {
+	// Minor is Go major release (e.g. 1.22) to upgrade to, major release of current version by default.
+	Minor string
+	// Check only reports whether upgrade is available.
+	Check bool
+	// Prune removes previous version after switch.
+	Prune bool
+}
+
+// UpgradeResult is result of Upgrade.
+type UpgradeResult struct {
+	Current string
+	// Latest is the newest patch release of requested major release.
+	Latest string
+	// Available is set if Latest is newer than Current.
+	Available bool
+	Install   *InstallResult
+	Use       *UseResult
+	// Pruned is set if Current is removed.
+	Pruned bool
+}
+
+// Upgrade installs and switches linkPath symlink to the latest patch release
+// of current (or requested) Go major release.
+// In check mode ErrUpgradeAvailable is returned together with result if upgrade is available.
+func (m *Manager) Upgrade(linkPath string, opts UpgradeOpts) (*UpgradeResult, error) {
+	if opts.Minor != "" && !majorReleaseRe.MatchString(opts.Minor) {
+		return nil, fmt.Errorf("major release like 1.22 is expected instead of %q", opts.Minor)
+	}
+	_, current, err := m.currentVersion(linkPath)
+	if err != nil {
+		return nil, err
+	}
+	currentInfo, err := parseVersionInfo(current)
+	if err != nil {
+		return nil, err
+	}
+
+	minor := opts.Minor
+	if minor == "" {
+		minor = majorMinor(currentInfo)
+	}
+	minorInfo, err := parseVersionInfo(minor)
+	if err != nil {
+		return nil, err
+	}
+
+	remotes, err := m.remoteVersions()
+	if err != nil {
+		return nil, err
+	}
+	newest := newestPatch(minorInfo, remotes)
+	if newest == nil {
+		return nil, &VersionNotFoundError{Spec: minor}
+	}
+	res := &UpgradeResult{Current: current, Latest: newest.original}
+	if newest.original == current || newest.semver.LessThan(*currentInfo.semver) {
+		if majorMinor(currentInfo) != majorMinor(minorInfo) {
+			return nil, fmt.Errorf("latest release of Go %s (%s) is older than current Go %s", minor, newest.original, current)
+		}
+		return res, nil
+	}
+
+	res.Available = true
+	if opts.Check {
+		return res, ErrUpgradeAvailable
+	}
+
+	fmt.Fprintf(m.Stdout, "Upgrade Go %s -> %s...\n", current, newest.original)
+	if res.Install, err = m.Install(newest.original, InstallOpts{}); err != nil {
+		return res, err
+	}
+	if res.Use, err = m.UseVersion(linkPath, newest.original); err != nil {
+		return res, fmt.Errorf("switch to %s is failed: %w", newest.original, err)
+	}
+
+	if opts.Prune {
+		fmt.Fprintf(m.Stdout, "Remove Go %s...\n", current)
+		if err := m.Uninstall(current); err != nil {
+			return res, fmt.Errorf("remove of %s is failed: %w", current, err)
+		}
+		res.Pruned = true
+	}
+	return res, nil
+}
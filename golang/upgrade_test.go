@@ -0,0 +1,89 @@
+package golang_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+func TestUpgradeInvalidMajorRelease(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	if err := env.AddSDK("1.21.3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Use("1.21.3"); err != nil {
+		t.Fatal(err)
+	}
+	for _, minor := range []string{"latest", "foo", "1.21.3", "2"} {
+		if _, err := m.Upgrade(env.GoBinLink, golang.UpgradeOpts{Minor: minor, Check: true}); err == nil {
+			t.Errorf("Upgrade(%q) error is expected", minor)
+		}
+	}
+
+	res, err := m.Upgrade(env.GoBinLink, golang.UpgradeOpts{Minor: "1.22", Check: true})
+	if !errors.Is(err, golang.ErrUpgradeAvailable) {
+		t.Fatalf("Upgrade(1.22) error = %v, ErrUpgradeAvailable is expected", err)
+	}
+	if res.Current != "1.21.3" || res.Latest != "1.22.1" {
+		t.Errorf("upgrade %s -> %s, want 1.21.3 -> 1.22.1", res.Current, res.Latest)
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	for _, prune := range []bool{false, true} {
+		env, m := newTestEnv(t, "1.21.3", "1.21.8", "1.22.1")
+		if err := env.AddSDK("1.21.3"); err != nil {
+			t.Fatal(err)
+		}
+		if err := env.Use("1.21.3"); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := m.Upgrade(env.GoBinLink, golang.UpgradeOpts{Prune: prune})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Current != "1.21.3" || res.Latest != "1.21.8" || !res.Available || res.Pruned != prune {
+			t.Errorf("prune %v: result %+v, upgrade 1.21.3 -> 1.21.8 is expected", prune, res)
+		}
+		if _, err := os.Stat(filepath.Join(env.SDKPath("1.21.8"), "VERSION")); err != nil {
+			t.Error(err)
+		}
+		target, err := os.Readlink(env.GoBinLink)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(env.SDKPath("1.21.8"), "bin", "go"); target != want {
+			t.Errorf("symlink target %s, want %s", target, want)
+		}
+
+		// previous version is kept without --prune
+		for _, file := range []string{env.SDKPath("1.21.3"), env.WrapperPath("1.21.3")} {
+			if _, err := os.Lstat(file); os.IsNotExist(err) != prune {
+				t.Errorf("prune %v: %s stat: %v", prune, file, err)
+			}
+		}
+
+		// the latest patch release is up to date
+		res, err = m.Upgrade(env.GoBinLink, golang.UpgradeOpts{Check: true})
+		if err != nil || res.Available {
+			t.Errorf("result %+v, %v, upgrade isn't expected", res, err)
+		}
+	}
+}
+
+func TestUpgradeToOlderMajorRelease(t *testing.T) {
+	env, m := newTestEnv(t, "1.21.8", "1.22.1")
+	if err := env.AddSDK("1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Use("1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Upgrade(env.GoBinLink, golang.UpgradeOpts{Minor: "1.21"}); err == nil {
+		t.Error("error is expected for major release older than current version")
+	}
+}
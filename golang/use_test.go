@@ -0,0 +1,117 @@
+package golang_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+func TestList(t *testing.T) {
+	env, m := newTestEnv(t)
+	for _, v := range []string{"1.21.3", "1.22.1"} {
+		if err := env.AddSDK(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := env.AddIDEASDK("1.20.5"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Use("1.21.3"); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := m.List(env.GoBinLink, golang.ListOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := map[string]bool{}
+	for _, v := range res.Installed {
+		current[v.Version] = v.Current
+	}
+	if len(current) != 2 || !current["1.21.3"] || current["1.22.1"] {
+		t.Errorf("installed versions %+v, want current 1.21.3 and 1.22.1", res.Installed)
+	}
+	if len(res.IDEA) != 1 || res.IDEA[0].Version != "1.20.5" || res.IDEA[0].Current {
+		t.Errorf("IDEA versions %+v, want 1.20.5", res.IDEA)
+	}
+	if !res.CurrentFound() {
+		t.Error("current version is not found")
+	}
+}
+
+func TestUsePatchesProject(t *testing.T) {
+	env, m := newTestEnv(t)
+	m.Prompter = alwaysYes
+	for _, v := range []string{"1.21.3", "1.22.1"} {
+		if err := env.AddSDK(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := env.Use("1.21.3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.WriteProjectFile("go.mod", "module example.com/p\n\ngo 1.21 // toolchain of team\n\nrequire golang.org/x/mod v0.5.1\n"); err != nil {
+		t.Fatal(err)
+	}
+	workspace := `<?xml version="1.0" encoding="UTF-8"?>
+<project version="4">
+  <component name="GOROOT" url="file://$USER_HOME$/sdk/go1.21.3" />
+  <component name="PropertiesComponent">
+    <property name="go.sdk.automatically.set" value="true" />
+  </component>
+</project>
+`
+	if err := env.WriteProjectFile(filepath.Join(".idea", "workspace.xml"), workspace); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := m.UseVersion(env.GoBinLink, "1.22.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := os.Readlink(env.GoBinLink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(env.SDKPath("1.22.1"), "bin", "go"); target != want {
+		t.Errorf("symlink target %s, want %s", target, want)
+	}
+
+	if res.GoMod != "1.22" {
+		t.Errorf("go.mod is patched to %q, want 1.22", res.GoMod)
+	}
+	goMod, err := env.ReadProjectFile("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireContains(t, goMod, "\ngo 1.22 // toolchain of team\n")
+	requireContains(t, goMod, "require golang.org/x/mod v0.5.1\n")
+
+	got, err := env.ReadProjectFile(filepath.Join(".idea", "workspace.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(workspace, "sdk/go1.21.3", "sdk/go1.22.1", 1)
+	if got != want {
+		t.Errorf("workspace.xml:\n%s\nwant:\n%s", got, want)
+	}
+	if len(res.Editors) != 1 || res.Editors[0].Previous != env.SDKPath("1.21.3") {
+		t.Errorf("editor changes %+v, want GoLand/IDEA change from %s", res.Editors, env.SDKPath("1.21.3"))
+	}
+
+	runner := m.Runner.(*golangtest.FakeRunner)
+	var modCalls []string
+	for _, c := range runner.Calls() {
+		if strings.HasPrefix(c, "go mod ") {
+			modCalls = append(modCalls, c)
+		}
+	}
+	if len(modCalls) != 2 || modCalls[0] != "go mod edit -go=1.22" {
+		t.Errorf("go mod commands %q, want edit and tidy", modCalls)
+	}
+}
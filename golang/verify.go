@@ -0,0 +1,229 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// VerifyOpts controls Verify behaviour.
+type VerifyOpts struct {
+	// Repair restores modified and missing files and removes added ones using release archive
+	// (archive is downloaded if it isn't cached).
+	Repair bool
+}
+
+// VerifyResult is result of SDK verification.
+type VerifyResult struct {
+	Version string
+	GOROOT  string
+	// ManifestRebuilt is set if SDK doesn't have manifest and it's rebuilt from cached release archive.
+	ManifestRebuilt bool
+	// Diff is difference between SDK files and manifest.
+	Diff *SDKDiff
+	// Repaired is set if SDK files are restored from release archive.
+	Repaired bool
+	Err      error
+}
+
+// OK reports whether SDK matches its manifest (or it's repaired).
+func (r *VerifyResult) OK() bool {
+	return r.Err == nil && r.Diff != nil && (r.Diff.Empty() || r.Repaired)
+}
+
+// Verify checks files of installed SDKs (all if versions are not provided) against manifests recorded at install time.
+// Manifest of SDK installed without it is rebuilt from cached release archive.
+// *ProblemsError is returned together with results if some SDKs don't match manifests.
+func (m *Manager) Verify(versions []string, opts VerifyOpts) ([]VerifyResult, error) {
+	if len(versions) == 0 {
+		var err error
+		if versions, err = m.installedSDKs(); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		results  []VerifyResult
+		problems []string
+	)
+	for _, version := range versions {
+		fmt.Fprintf(m.Stdout, "Verify Go %s...\n", version)
+		res := m.verifySDK(version, opts)
+		switch {
+		case res.Err != nil:
+			problems = append(problems, fmt.Sprintf("Go %s: %v", version, res.Err))
+		case !res.OK():
+			problems = append(problems, fmt.Sprintf("Go %s is modified: %s", version, res.Diff))
+		}
+		results = append(results, res)
+	}
+	if len(problems) > 0 {
+		return results, &ProblemsError{Problems: problems}
+	}
+	return results, nil
+}
+
+// installedSDKs returns versions of SDKs unpacked to SDKDir.
+func (m *Manager) installedSDKs() ([]string, error) {
+	dirs, err := filepath.Glob(filepath.Join(m.SDKDir, "go1.*"))
+	if err != nil {
+		return nil, fmt.Errorf("list of %v is failed: %w", m.SDKDir, err)
+	}
+	var versions versionList
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if strings.Contains(name, ".tmp-") || !fileExists(filepath.Join(dir, unpackedMarker)) {
+			continue
+		}
+		v, err := parseVersionInfo(name[2:])
+		if err != nil {
+			continue
+		}
+		versions = append(versions, *v)
+	}
+	sort.Sort(versions)
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, v.original)
+	}
+	return names, nil
+}
+
+func (m *Manager) verifySDK(version string, opts VerifyOpts) VerifyResult {
+	sdkDir := m.sdkPath(version)
+	res := VerifyResult{Version: version, GOROOT: sdkDir}
+	if !fileExists(filepath.Join(sdkDir, unpackedMarker)) {
+		res.Err = fmt.Errorf("Go %s is not installed to %s", version, sdkDir)
+		return res
+	}
+
+	// unpacked is temporary directory with release archive content
+	var unpacked string
+	defer func() {
+		if unpacked != "" {
+			os.RemoveAll(unpacked)
+		}
+	}()
+
+	mf, err := readManifest(sdkDir)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	if mf == nil {
+		archiveName := archiveFilename(version, runtime.GOOS, runtime.GOARCH)
+		archive, err := m.sdkArchive(version, archiveName, "", opts.Repair)
+		if err != nil {
+			res.Err = fmt.Errorf("SDK doesn't have manifest: %w", err)
+			return res
+		}
+		if unpacked, err = m.unpackTemp(archive); err != nil {
+			res.Err = err
+			return res
+		}
+		if mf, err = newManifest(unpacked, archive, archiveName); err != nil {
+			res.Err = err
+			return res
+		}
+		if mf.Version != version {
+			res.Err = fmt.Errorf("%s contains Go %s", archiveName, mf.Version)
+			return res
+		}
+		m.Logger.Debug("manifest is rebuilt from archive", "goroot", sdkDir, "archive", archive)
+		if err := mf.write(sdkDir); err != nil {
+			res.Err = err
+			return res
+		}
+		res.ManifestRebuilt = true
+	}
+
+	files, err := sdkFileHashes(sdkDir)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.Diff = diffFiles(mf.Files, files)
+	if res.Diff.Empty() || !opts.Repair {
+		return res
+	}
+
+	if unpacked == "" {
+		archive, err := m.sdkArchive(version, mf.Archive, mf.ArchiveSHA256, true)
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		if unpacked, err = m.unpackTemp(archive); err != nil {
+			res.Err = err
+			return res
+		}
+	}
+	fmt.Fprintf(m.Stdout, "Repair %s (%s)...\n", sdkDir, res.Diff)
+	if err := repairSDK(sdkDir, unpacked, res.Diff); err != nil {
+		res.Err = fmt.Errorf("repair is failed: %w", err)
+		return res
+	}
+	res.Repaired = true
+	return res
+}
+
+// sdkArchive returns cached release archive of version matching checksum (if it isn't empty),
+// archive is downloaded if it isn't cached and download is set.
+func (m *Manager) sdkArchive(version string, archiveName string, checksum string, download bool) (string, error) {
+	archive, err := m.cachedArchive(archiveName, checksum)
+	if err != nil || archive != "" {
+		return archive, err
+	}
+	if !download {
+		return "", fmt.Errorf("%s is not cached (run `golangver verify --repair %s` to download it)", archiveName, version)
+	}
+	if archive, err = m.fetchArchive(m.Stdout, version, runtime.GOOS, runtime.GOARCH, InstallOpts{}); err != nil {
+		return "", err
+	}
+	if err := verifyChecksum(archive, checksum); err != nil {
+		return "", fmt.Errorf("%s doesn't match manifest: %w", archiveName, err)
+	}
+	return archive, nil
+}
+
+// unpackTemp unpacks archive to temporary directory in SDKDir (so its files can be moved to SDK).
+func (m *Manager) unpackTemp(archive string) (string, error) {
+	if err := os.MkdirAll(m.SDKDir, 0755); err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp(m.SDKDir, ".verify-*")
+	if err != nil {
+		return "", err
+	}
+	if err := unpackArchive(archive, dir); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("unpack of %s is failed: %w", archive, err)
+	}
+	return dir, nil
+}
+
+// repairSDK restores modified and missing files of sdkDir from unpacked archive and removes added files.
+func repairSDK(sdkDir string, unpacked string, diff *SDKDiff) error {
+	for _, name := range append(append([]string(nil), diff.Modified...), diff.Missing...) {
+		src := filepath.Join(unpacked, filepath.FromSlash(name))
+		dst := filepath.Join(sdkDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+	}
+	for _, name := range diff.Added {
+		if err := os.Remove(filepath.Join(sdkDir, filepath.FromSlash(name))); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
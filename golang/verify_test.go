@@ -0,0 +1,85 @@
+package golang_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+func TestVerifyAndRepair(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	sdk := env.SDKPath("1.22.1")
+	goMod := filepath.Join(sdk, "src", "go.mod")
+	orig, err := os.ReadFile(goMod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(goMod, []byte("module std // edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(sdk, "VERSION")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sdk, "src", "extra.go"), []byte("package extra\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := m.Verify([]string{"1.22.1"}, golang.VerifyOpts{})
+	var problems *golang.ProblemsError
+	if !errors.As(err, &problems) {
+		t.Fatalf("error = %v, *ProblemsError is expected", err)
+	}
+	want := &golang.SDKDiff{Added: []string{"src/extra.go"}, Modified: []string{"src/go.mod"}, Missing: []string{"VERSION"}}
+	if len(results) != 1 || !reflect.DeepEqual(results[0].Diff, want) {
+		t.Fatalf("results %+v, want diff %+v", results, want)
+	}
+
+	results, err = m.Verify([]string{"1.22.1"}, golang.VerifyOpts{Repair: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Repaired {
+		t.Errorf("result %+v, repair is expected", results[0])
+	}
+	if b, err := os.ReadFile(goMod); err != nil || string(b) != string(orig) {
+		t.Errorf("src/go.mod %q, %v, want %q", b, err, orig)
+	}
+	if _, err := os.Stat(filepath.Join(sdk, "VERSION")); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(sdk, "src", "extra.go")); !os.IsNotExist(err) {
+		t.Errorf("added file isn't removed: %v", err)
+	}
+	if results, err := m.Verify(nil, golang.VerifyOpts{}); err != nil || len(results) != 1 || !results[0].Diff.Empty() {
+		t.Errorf("results %+v, %v after repair", results, err)
+	}
+}
+
+func TestVerifyRebuildsManifest(t *testing.T) {
+	env, m := newTestEnv(t, "1.22.1")
+	if _, err := m.Install("1.22.1", golang.InstallOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	manifest := filepath.Join(env.SDKPath("1.22.1"), ".golangver-manifest.json")
+	if err := os.Remove(manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := m.Verify([]string{"1.22.1"}, golang.VerifyOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].ManifestRebuilt || !results[0].Diff.Empty() {
+		t.Errorf("result %+v, rebuilt manifest without differences is expected", results[0])
+	}
+	if _, err := os.Stat(manifest); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,204 @@
+package golang
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// versionRef is Go version reference found in project file.
+type versionRef struct {
+	file string
+	kind string
+	// minimum is true if reference declares minimum required version (go directive).
+	minimum bool
+	// line is 1-based line number.
+	line int
+	// start and end are offsets of version in line.
+	start, end int
+	value      string
+}
+
+// versionRefPattern finds Go version in lines of specific files.
+type versionRefPattern struct {
+	kind    string
+	minimum bool
+	match   func(path string) bool
+	// re must have version as the first group.
+	re *regexp.Regexp
+}
+
+const versionRePart = `(\d+\.\d+(?:\.\d+)?(?:(?:rc|beta)\d+)?)`
+
+// versionRe matches Go version (1.20, 1.21.3, 1.22rc1).
+var versionRe = regexp.MustCompile(`^` + versionRePart + `$`)
+
+// versionRefPatterns find Go version in Dockerfiles, CI configs and .tool-versions.
+var versionRefPatterns = []versionRefPattern{
+	{
+		kind:  "Dockerfile",
+		match: isDockerfile,
+		re:    regexp.MustCompile(`(?i)^\s*FROM\s+(?:--\S+\s+)*(?:\S+/)?golang:` + versionRePart),
+	},
+	{
+		kind:  "GitHub Actions",
+		match: isGitHubWorkflow,
+		re:    regexp.MustCompile(`^\s*(?:-\s+)?go-version\s*:\s*['"]?` + versionRePart),
+	},
+	{
+		kind: "GitLab CI",
+		match: func(path string) bool {
+			return path == ".gitlab-ci.yml"
+		},
+		re: regexp.MustCompile(`^\s*(?:-\s+)?(?:image|name)\s*:\s*['"]?(?:\S+/)?golang:` + versionRePart),
+	},
+	{
+		kind: ".tool-versions",
+		match: func(path string) bool {
+			return path == ".tool-versions"
+		},
+		re: regexp.MustCompile(`^\s*golang\s+` + versionRePart),
+	},
+}
+
+// goDirectivePattern finds go directive in go.mod and go.work,
+// it's used both by Check and by UseVersion (patch of go.mod), so they can't disagree about the same file.
+var goDirectivePattern = versionRefPattern{
+	kind:    "go directive",
+	minimum: true,
+	match:   isGoModOrWork,
+	re:      regexp.MustCompile(`^go\s+` + versionRePart),
+}
+
+// goVersionFilePatterns find Go version in go.mod, go.work and .go-version files.
+var goVersionFilePatterns = []versionRefPattern{
+	goDirectivePattern,
+	{
+		kind:  "toolchain",
+		match: isGoModOrWork,
+		re:    regexp.MustCompile(`^toolchain\s+go` + versionRePart),
+	},
+	{
+		kind: ".go-version",
+		match: func(path string) bool {
+			return path == ".go-version"
+		},
+		re: regexp.MustCompile(`^\s*v?(?:go)?` + versionRePart),
+	},
+}
+
+// goDirective returns go directive of go.mod or go.work file (nil if file doesn't declare it).
+func goDirective(file string) (*versionRef, error) {
+	refs, err := findVersionRefsInFile(file, goDirectivePattern)
+	if err != nil || len(refs) == 0 {
+		return nil, err
+	}
+	return &refs[0], nil
+}
+
+func isGoModOrWork(path string) bool {
+	return path == goModFile || path == "go.work"
+}
+
+func isDockerfile(path string) bool {
+	name := filepath.Base(path)
+	return name == "Dockerfile" || name == "Containerfile" ||
+		strings.HasPrefix(name, "Dockerfile.") || strings.HasSuffix(name, ".Dockerfile")
+}
+
+func isGitHubWorkflow(path string) bool {
+	dir, name := filepath.Split(path)
+	if filepath.Clean(dir) != filepath.Join(".github", "workflows") {
+		return false
+	}
+	ext := filepath.Ext(name)
+	return ext == ".yml" || ext == ".yaml"
+}
+
+// skipDirs are not scanned for version references.
+var skipDirs = map[string]bool{
+	".git":         true,
+	".idea":        true,
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+}
+
+// findVersionRefs finds Go version references matching patterns in files under root.
+func findVersionRefs(root string, patterns []versionRefPattern) ([]versionRef, error) {
+	var refs []versionRef
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if rel != "." && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		for _, p := range patterns {
+			if !p.match(rel) {
+				continue
+			}
+			found, err := findVersionRefsInFile(path, p)
+			if err != nil {
+				return err
+			}
+			refs = append(refs, found...)
+		}
+		return nil
+	})
+	return refs, err
+}
+
+func findVersionRefsInFile(file string, p versionRefPattern) ([]versionRef, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []versionRef
+	for i, line := range strings.Split(string(b), "\n") {
+		m := p.re.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		refs = append(refs, versionRef{
+			file:    file,
+			kind:    p.kind,
+			minimum: p.minimum,
+			line:    i + 1,
+			start:   m[2],
+			end:     m[3],
+			value:   line[m[2]:m[3]],
+		})
+	}
+	return refs, nil
+}
+
+// bumpVersion returns version formatted with precision of current.
+// Example: current=1.21, version=1.22.3 -> 1.22.
+func bumpVersion(current string, version string) string {
+	if strings.Count(current, ".") != 1 || strings.IndexFunc(current, unicode.IsLetter) >= 0 {
+		return version
+	}
+	v, err := parseVersionInfo(version)
+	if err != nil {
+		return version
+	}
+	return majorMinor(v)
+}
+
+func majorMinor(v *versionInfo) string {
+	return fmt.Sprintf("%d.%d", v.semver.Major, v.semver.Minor)
+}
@@ -28,7 +28,7 @@ func fixVersion(version string) string {
 	match := lastVersionPartRe.FindStringSubmatch(lastPart)
 
 	// fmt.Println(" fixVersion/match:", match)
-	if match[2] != "" {
+	if match != nil && match[2] != "" {
 		// fmt.Println("lastPart:", match[2])
 		versionParts[len(versionParts)-1] = match[1]
 	}
@@ -44,6 +44,9 @@ func parseVersionInfo(version string) (*versionInfo, error) {
 	versionParts := strings.Split(version, ".")
 	lastPart := versionParts[len(versionParts)-1]
 	match := lastVersionPartRe.FindStringSubmatch(lastPart)
+	if match == nil {
+		return nil, fmt.Errorf("failed to parse %v: version must end with number", version)
+	}
 
 	if match[2] != "" {
 		versionParts[len(versionParts)-1] = match[1]
@@ -85,3 +88,13 @@ func (v versionList) Less(i, j int) bool {
 func (v versionList) Sort() {
 	sort.Sort(v)
 }
+
+// contains reports whether list contains version.
+func (v versionList) contains(version string) bool {
+	for _, vi := range v {
+		if vi.original == version {
+			return true
+		}
+	}
+	return false
+}
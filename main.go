@@ -1,32 +1,67 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/uitools"
 )
 
 func main() {
 	newApp().runE(os.Args)
 }
 
+func init() {
+	// -v is alias of --verbose
+	cli.VersionFlag = &cli.BoolFlag{Name: "version", Usage: "print the version"}
+}
+
 const goBinPathDefault = "/usr/local/bin/go"
 
+// exitUpgradeAvailable is exit code of `upgrade --check` if upgrade is available.
+const exitUpgradeAvailable = 3
+
 type app struct {
-	app       *cli.App
-	goBinPath string
-	verbose   bool
+	app *cli.App
+	m   *golang.Manager
+	// newManager creates Manager for commands (interactive one by default,
+	// golangtest.Env.Manager can be used to run CLI in fake environment).
+	newManager func() (*golang.Manager, error)
+	goBinPath  string
+	verbose    bool
+	logFormat  string
+	mirror     string
+	indexURL   string
+	caBundle   string
+	policy     string
+	// version is version of golangver binary ("(devel)" for local builds).
+	version      string
+	moduleProxy  string
+	refresh      bool
+	updateNotice bool
+	// completing is set if command line is completed by shell completion script.
+	completing bool
 }
 
 func newApp() *app {
-	a := &app{}
+	a := &app{newManager: newInteractiveManager, version: selfVersion()}
 	a.app = &cli.App{
-		Name:  "golangver",
-		Usage: "golang binaries version manager",
+		Name:                 "golangver",
+		Usage:                "golang binaries version manager",
+		Version:              a.version,
+		EnableBashCompletion: true,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:        "verbose",
@@ -34,19 +69,190 @@ func newApp() *app {
 				Aliases:     []string{"v"},
 				Destination: &a.verbose,
 			},
+			&cli.StringFlag{
+				Name:        "log-format",
+				Usage:       "format of logs: text or json (logs are written to stderr, debug logs require -v)",
+				Value:       "text",
+				Destination: &a.logFormat,
+			},
 			&cli.StringFlag{
 				Name:        "go-bin",
 				Usage:       "symlink to Go binary",
 				Value:       goBinPathDefault,
 				Destination: &a.goBinPath,
 			},
+			&cli.StringFlag{
+				Name:        "mirror",
+				Usage:       "base URL of Go downloads mirror (overrides config)",
+				EnvVars:     []string{"GOLANGVER_MIRROR"},
+				Destination: &a.mirror,
+			},
+			&cli.StringFlag{
+				Name:        "index-url",
+				Usage:       "URL of Go releases index (overrides config)",
+				EnvVars:     []string{"GOLANGVER_INDEX_URL"},
+				Destination: &a.indexURL,
+			},
+			&cli.StringFlag{
+				Name:        "ca-bundle",
+				Usage:       "PEM file with additional CA certificates (overrides config)",
+				EnvVars:     []string{"GOLANGVER_CA_BUNDLE"},
+				Destination: &a.caBundle,
+			},
+			&cli.StringFlag{
+				Name:        "policy",
+				Usage:       "path or URL of team policy file (overrides config and " + golang.PolicyFile + " of project)",
+				EnvVars:     []string{"GOLANGVER_POLICY"},
+				Destination: &a.policy,
+			},
+			&cli.StringFlag{
+				Name:        "module-proxy",
+				Usage:       "base URL of Go module proxy golangver releases are checked in (overrides config)",
+				EnvVars:     []string{"GOLANGVER_MODULE_PROXY"},
+				Destination: &a.moduleProxy,
+			},
+			&cli.BoolFlag{
+				Name:        "refresh",
+				Usage:       "fetch releases index even if cached one is fresh (see index_ttl config)",
+				Destination: &a.refresh,
+			},
+		},
+		Before: func(cliCtx *cli.Context) error {
+			return a.setup()
+		},
+		After: func(cliCtx *cli.Context) error {
+			a.notifyUpdate(cliCtx.Args().First())
+			return nil
 		},
 	}
 	a.addFlags()
 	return a
 }
 
+// setup creates interactive Manager configured by config file and global flags.
+func (a *app) setup() error {
+	logger, err := newLogger(a.verbose, a.logFormat)
+	if err != nil {
+		return err
+	}
+	m, err := a.newManager()
+	if err != nil {
+		return err
+	}
+	m.Logger = logger
+	a.m = m
+
+	cfg, err := golang.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if a.mirror != "" {
+		cfg.Mirror = a.mirror
+	}
+	if a.indexURL != "" {
+		cfg.IndexURL = a.indexURL
+	}
+	if a.caBundle != "" {
+		cfg.CABundle = a.caBundle
+	}
+	if a.policy != "" {
+		cfg.Policy = a.policy
+	}
+	if a.moduleProxy != "" {
+		cfg.ModuleProxy = a.moduleProxy
+	}
+	a.updateNotice = cfg.UpdateNotice
+	if err := m.ApplyConfig(cfg); err != nil {
+		return err
+	}
+	m.RefreshIndex = a.refresh
+	return nil
+}
+
+// updateNoticeInterval is minimal interval between checks of golangver releases for update notice.
+const updateNoticeInterval = 24 * time.Hour
+
+// notifyUpdate writes notice about new golangver release to stderr if it's enabled by config.
+// Commands which output can be consumed by other programs are skipped.
+func (a *app) notifyUpdate(command string) {
+	if a.m == nil || !a.updateNotice || a.completing {
+		return
+	}
+	switch command {
+	case "", "self-update", "exec", "env", "completion", "man":
+		return
+	}
+	latest, err := a.m.SelfUpdateNotice(a.version, updateNoticeInterval)
+	if err != nil {
+		a.m.Logger.Debug("update notice check is failed", "err", err)
+		return
+	}
+	if latest != "" {
+		fmt.Fprintf(a.m.Stderr, "\nNOTICE: golangver %s is available (current is %s), run `golangver self-update`\n", latest, a.version)
+	}
+}
+
+// selfVersion returns version of golangver binary from its build info ("(devel)" for local builds).
+func selfVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	return info.Main.Version
+}
+
+// selfBinDir returns directory of running golangver binary.
+func selfBinDir() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return "", err
+	}
+	return filepath.Dir(exe), nil
+}
+
+// newInteractiveManager returns Manager which writes to stdout/stderr and prompts on terminal.
+func newInteractiveManager() (*golang.Manager, error) {
+	m, err := golang.NewManager()
+	if err != nil {
+		return nil, err
+	}
+	m.Stdin = os.Stdin
+	m.Stdout = os.Stdout
+	m.Stderr = os.Stderr
+	m.Prompter = golang.PrompterFunc(uitools.InputYesNo)
+	return m, nil
+}
+
+// newLogger returns logger writing to stderr: human readable text or JSON for CI.
+func newLogger(verbose bool, format string) (*slog.Logger, error) {
+	level := slog.LevelWarn
+	if verbose {
+		level = slog.LevelDebug
+	}
+
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+			Level: level,
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				// timestamps are noise in interactive output
+				if len(groups) == 0 && attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return attr
+			},
+		})), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})), nil
+	}
+	return nil, fmt.Errorf("unknown log format: %s", format)
+}
+
 func (a *app) run(args []string) error {
+	a.completing = len(args) > 0 && args[len(args)-1] == completionFlag
 	return a.app.Run(args)
 }
 
@@ -58,29 +264,80 @@ func (a *app) runE(args []string) {
 }
 
 func (a *app) addFlags() {
-	var forceInstall bool
+	var installOpts golang.InstallOpts
+	var fetchOpts golang.FetchOpts
 	cInstall := &cli.Command{
-		Name:  "get",
-		Usage: "fetch version from https://go.dev/dl/",
+		Name:         "get",
+		Usage:        "fetch versions from https://go.dev/dl/ (1.N is the newest 1.N.x, =1.N is the initial release of Go before 1.21)",
+		ArgsUsage:    "version|1.N|stable|constraint...",
+		BashComplete: a.completeVersions(true),
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:        "force",
 				Aliases:     []string{"f"},
-				Usage:       "remove if distro exists locally before fecth",
-				Destination: &forceInstall,
+				Usage:       "remove if distro exists locally before fecth (with --dest: replace SDK fetched to it before)",
+				Destination: &installOpts.Force,
+			},
+			&cli.BoolFlag{
+				Name:        "offline",
+				Usage:       "install from cached archives (or --from-dir) only",
+				Destination: &installOpts.Offline,
+			},
+			&cli.StringFlag{
+				Name:        "from-dir",
+				Usage:       "directory with Go release archives (go<version>.<os>-<arch>.tar.gz)",
+				Destination: &installOpts.FromDir,
+			},
+			&cli.IntFlag{
+				Name:        "jobs",
+				Aliases:     []string{"j"},
+				Usage:       "number of concurrent downloads",
+				Value:       3,
+				Destination: &installOpts.Jobs,
+			},
+			&cli.StringFlag{
+				Name:        "os",
+				Usage:       "target GOOS of SDK unpacked to --dest (it isn't registered as local version)",
+				Destination: &fetchOpts.OS,
+			},
+			&cli.StringFlag{
+				Name:        "arch",
+				Usage:       "target GOARCH of SDK unpacked to --dest (it isn't registered as local version)",
+				Destination: &fetchOpts.Arch,
+			},
+			&cli.StringFlag{
+				Name:        "dest",
+				Usage:       "unpack SDK to directory without registering it as local version (go<version>.<os>-<arch> by default)",
+				Destination: &fetchOpts.Dest,
 			},
 		},
 		Action: func(cliCtx *cli.Context) error {
-			args := cliCtx.Args()
-			version := args.Get(0)
-			if version == "" {
+			specs := cliCtx.Args().Slice()
+			if len(specs) == 0 {
 				return fmt.Errorf("version is not provided")
 			}
-			if version[0] == 'v' {
-				version = version[1:]
+			if installOpts.Offline {
+				for _, spec := range specs {
+					if !golang.IsExactVersion(spec) {
+						return fmt.Errorf("exact version is required in offline mode: %s", spec)
+					}
+				}
+			}
+			versions, err := a.m.ResolveVersions(specs)
+			if err != nil {
+				return err
+			}
+			if fetchOpts.OS != "" || fetchOpts.Arch != "" || fetchOpts.Dest != "" {
+				return a.fetch(versions, fetchOpts, installOpts)
+			}
+			if len(versions) > 1 {
+				results, err := a.m.InstallAll(versions, installOpts)
+				printInstallSummary(results)
+				return err
 			}
 
-			if err := golang.Install(version, forceInstall); err != nil {
+			version := versions[0]
+			if _, err := a.m.Install(version, installOpts); err != nil {
 				return err
 			}
 			fmt.Println()
@@ -88,7 +345,58 @@ func (a *app) addFlags() {
 			fmt.Printf("Now you can use Go %s:\n", version)
 			fmt.Println("* with command:", "v-service go use", version)
 			fmt.Println(" OR")
-			fmt.Printf("* set Go path: export PATH=%s:$PATH\n", mustGoBinByVersion(version))
+			fmt.Printf("* set Go path: export PATH=%s:$PATH\n", a.mustGoBinByVersion(version))
+			return nil
+		},
+	}
+
+	cUninstall := &cli.Command{
+		Name:         "uninstall",
+		Usage:        "remove SDKs and go<version> binaries of versions",
+		ArgsUsage:    "version...",
+		BashComplete: a.completeVersions(true),
+		Action: func(cliCtx *cli.Context) error {
+			versions := cliCtx.Args().Slice()
+			if len(versions) == 0 {
+				return fmt.Errorf("version is not provided")
+			}
+			for _, version := range versions {
+				version = strings.TrimPrefix(version, "v")
+				if !golang.IsExactVersion(version) {
+					return fmt.Errorf("exact version is required: %s", version)
+				}
+				if err := a.m.Uninstall(version); err != nil {
+					return fmt.Errorf("uninstall of Go %s is failed: %w", version, err)
+				}
+				fmt.Printf("Go %s is uninstalled\n", version)
+			}
+			return nil
+		},
+	}
+
+	var forceImport bool
+	cImport := &cli.Command{
+		Name:      "import",
+		Usage:     "install Go from release archive (go<version>.<os>-<arch>.tar.gz)",
+		ArgsUsage: "archive",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "replace version if it's installed",
+				Destination: &forceImport,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			archive := cliCtx.Args().Get(0)
+			if archive == "" {
+				return fmt.Errorf("archive is not provided")
+			}
+			res, err := a.m.Import(archive, forceImport)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Now you can use Go %s with command: golangver use %s\n", res.Version, res.Version)
 			return nil
 		},
 	}
@@ -116,15 +424,27 @@ func (a *app) addFlags() {
 				Aliases:     []string{"o"},
 				Destination: &listOpts.ShowOutdated,
 			},
+			&cli.BoolFlag{
+				Name:        "status",
+				Usage:       "mark versions as supported, EOL or superseded by patch release (implied by -r)",
+				Aliases:     []string{"s"},
+				Destination: &listOpts.ShowStatus,
+			},
 		},
 		Action: func(cliCtx *cli.Context) error {
-			return golang.List(a.goBinPath, listOpts)
+			res, err := a.m.List(a.goBinPath, listOpts)
+			if err != nil {
+				return err
+			}
+			printList(res)
+			return nil
 		},
 	}
 
 	cUse := &cli.Command{
-		Name:  "use",
-		Usage: "use provided version",
+		Name:         "use",
+		Usage:        "use provided version",
+		BashComplete: a.completeVersions(false),
 		Action: func(cliCtx *cli.Context) error {
 			args := cliCtx.Args()
 			version := args.Get(0)
@@ -133,25 +453,565 @@ func (a *app) addFlags() {
 			}
 
 			if version[0] == filepath.Separator {
-				return golang.UseBinary(a.goBinPath, version)
+				_, err := a.m.UseBinary(a.goBinPath, version)
+				return err
 			}
 
 			if version[0] == 'v' {
 				version = version[1:]
 			}
-			err := golang.UseVersion(a.goBinPath, version)
+			res, err := a.m.UseVersion(a.goBinPath, version)
+			if res != nil {
+				printUse(res)
+			}
 			if err != nil {
 				return fmt.Errorf("switch to %s is failed: %w", version, err)
 			}
+			if tools, err := a.m.Tools(a.goBinPath); err == nil && len(tools.Outdated()) > 0 {
+				fmt.Printf("\n%d tool(s) in GOPATH/bin are built with other Go release, run `golangver tools` to rebuild them\n",
+					len(tools.Outdated()))
+			}
+			return nil
+		},
+	}
+
+	cDoctor := &cli.Command{
+		Name:  "doctor",
+		Usage: "report editors which point at Go SDK not matching to current version, modified SDK files and mismatch with " + golang.LockFile,
+		Action: func(cliCtx *cli.Context) error {
+			res, err := a.m.Doctor(a.goBinPath)
+			if res != nil {
+				printDoctor(res)
+			}
+			return err
+		},
+	}
+
+	var lockPlatforms cli.StringSlice
+	cLock := &cli.Command{
+		Name:      "lock",
+		Usage:     "write " + golang.LockFile + " with Go version (current by default) and sha256 of its release archives",
+		ArgsUsage: "[version|1.N|stable|constraint]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "platform",
+				Usage:       "platforms <os>/<arch> to pin archives of (current one and ones of existing lock file by default)",
+				Destination: &lockPlatforms,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			res, err := a.m.Lock(a.goBinPath, golang.LockOpts{
+				Version:   strings.TrimPrefix(cliCtx.Args().Get(0), "v"),
+				Platforms: splitValues(lockPlatforms.Value()),
+			})
+			if err != nil {
+				return err
+			}
+			printLock(res)
+			return nil
+		},
+	}
+
+	var verifyOpts golang.VerifyOpts
+	cVerify := &cli.Command{
+		Name:      "verify",
+		Usage:     "check files of installed SDKs (all by default) against manifests recorded at install time (or rebuilt from cached archives)",
+		ArgsUsage: "[version...]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "repair",
+				Usage:       "restore modified and missing files and remove added ones using release archive",
+				Destination: &verifyOpts.Repair,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			var versions []string
+			for _, v := range cliCtx.Args().Slice() {
+				versions = append(versions, strings.TrimPrefix(v, "v"))
+			}
+			results, err := a.m.Verify(versions, verifyOpts)
+			printVerify(results)
+			return err
+		},
+	}
+
+	cSync := &cli.Command{
+		Name:      "sync",
+		Usage:     "set Go version in Dockerfiles, CI configs and .tool-versions (current version by default)",
+		ArgsUsage: "[version]",
+		Action: func(cliCtx *cli.Context) error {
+			version := cliCtx.Args().Get(0)
+			if version != "" && version[0] == 'v' {
+				version = version[1:]
+			}
+			res, err := a.m.Sync(a.goBinPath, version)
+			if res != nil {
+				printSync(res)
+			}
+			return err
+		},
+	}
+
+	var checkOpts golang.CheckOpts
+	cCheck := &cli.Command{
+		Name:  "check",
+		Usage: "check that Go versions declared in project agree and are not end-of-life",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "skip-eol",
+				Usage:       "don't check end-of-life versions (requires remote versions list)",
+				Destination: &checkOpts.SkipEOL,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			res, err := a.m.Check(checkOpts)
+			if res != nil {
+				printCheck(res)
+			}
+			return err
+		},
+	}
+
+	var upgradeOpts golang.UpgradeOpts
+	cUpgrade := &cli.Command{
+		Name:      "upgrade",
+		Usage:     "get and use the latest patch release of current (or provided) Go major release",
+		ArgsUsage: "[1.N]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "check",
+				Usage:       fmt.Sprintf("only report whether upgrade is available (exit code is %d if it is)", exitUpgradeAvailable),
+				Aliases:     []string{"c"},
+				Destination: &upgradeOpts.Check,
+			},
+			&cli.BoolFlag{
+				Name:        "prune",
+				Usage:       "remove previous version after upgrade",
+				Destination: &upgradeOpts.Prune,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			upgradeOpts.Minor = strings.TrimPrefix(cliCtx.Args().Get(0), "v")
+			res, err := a.m.Upgrade(a.goBinPath, upgradeOpts)
+			if res != nil {
+				printUpgrade(res)
+			}
+			if errors.Is(err, golang.ErrUpgradeAvailable) {
+				return cli.Exit("", exitUpgradeAvailable)
+			}
+			return err
+		},
+	}
+
+	var selfUpdateOpts golang.SelfUpdateOpts
+	cSelfUpdate := &cli.Command{
+		Name:      "self-update",
+		Usage:     "install the latest (or provided) golangver release with current Go",
+		ArgsUsage: "[version]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "check",
+				Usage:       fmt.Sprintf("only report whether update is available (exit code is %d if it is)", exitUpgradeAvailable),
+				Aliases:     []string{"c"},
+				Destination: &selfUpdateOpts.Check,
+			},
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "install even if version isn't newer or golangver is built from local sources",
+				Destination: &selfUpdateOpts.Force,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			binDir, err := selfBinDir()
+			if err != nil {
+				return fmt.Errorf("golangver binary resolving is failed: %w", err)
+			}
+			selfUpdateOpts.Current = a.version
+			selfUpdateOpts.Version = cliCtx.Args().Get(0)
+			selfUpdateOpts.BinDir = binDir
+			res, err := a.m.SelfUpdate(selfUpdateOpts)
+			if res != nil {
+				printSelfUpdate(res)
+			}
+			if errors.Is(err, golang.ErrUpgradeAvailable) {
+				return cli.Exit("", exitUpgradeAvailable)
+			}
+			return err
+		},
+	}
+
+	var rebuildAll bool
+	cTools := &cli.Command{
+		Name:  "tools",
+		Usage: "show Go versions of binaries in GOPATH/bin and rebuild outdated ones with current Go version",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "all",
+				Aliases:     []string{"a"},
+				Usage:       "rebuild all tools, not only built with other Go major release",
+				Destination: &rebuildAll,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			res, err := a.m.Tools(a.goBinPath)
+			if err != nil {
+				return err
+			}
+			printTools(res)
+
+			candidates := res.Outdated()
+			if rebuildAll {
+				candidates = res.Tools
+			}
+			var tools []golang.Tool
+			for _, t := range candidates {
+				if !t.Reinstallable() {
+					fmt.Printf("skip %s: it's built from local sources\n", t.Name)
+					continue
+				}
+				tools = append(tools, t)
+			}
+			if len(tools) == 0 {
+				return nil
+			}
+			yes, err := a.m.Prompter.Confirm(fmt.Sprintf("\nDo you want to rebuild %d tool(s) with Go %s?", len(tools), res.GoVersion), false)
+			if err != nil || !yes {
+				return err
+			}
+			results, err := a.m.RebuildTools(a.goBinPath, tools)
+			printToolRebuilds(results)
+			return err
+		},
+	}
+
+	var (
+		matrixOpts  golang.MatrixOpts
+		matrixJUnit string
+		matrixJSON  string
+		matrixSpecs cli.StringSlice
+	)
+	cMatrix := &cli.Command{
+		Name:      "matrix",
+		Usage:     "run command with every provided installed Go version concurrently (like exec) and report results",
+		ArgsUsage: "-- command [args...]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "versions",
+				Usage:       "installed Go versions: exact, 1.N (the newest installed patch), stable or constraint (comma-separated)",
+				Required:    true,
+				Destination: &matrixSpecs,
+			},
+			&cli.IntFlag{
+				Name:        "jobs",
+				Aliases:     []string{"j"},
+				Usage:       "number of concurrent runs",
+				Value:       runtime.NumCPU(),
+				Destination: &matrixOpts.Jobs,
+			},
+			&cli.StringFlag{
+				Name:        "junit",
+				Usage:       "write JUnit XML report to file",
+				Destination: &matrixJUnit,
+			},
+			&cli.StringFlag{
+				Name:        "json",
+				Usage:       "write JSON report to file",
+				Destination: &matrixJSON,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			args := cliCtx.Args().Slice()
+			if len(args) > 0 && args[0] == "--" {
+				args = args[1:]
+			}
+			matrixOpts.Versions = splitValues(matrixSpecs.Value())
+			started := time.Now()
+			runs, err := a.m.Matrix(matrixOpts, args)
+			if runs == nil {
+				return err
+			}
+			printMatrix(runs)
+			if matrixJUnit != "" {
+				if err := writeReport(matrixJUnit, func(w io.Writer) error {
+					return golang.WriteMatrixJUnit(w, args, runs, started)
+				}); err != nil {
+					return err
+				}
+			}
+			if matrixJSON != "" {
+				if err := writeReport(matrixJSON, func(w io.Writer) error {
+					return golang.WriteMatrixJSON(w, args, runs, started)
+				}); err != nil {
+					return err
+				}
+			}
+			return err
+		},
+	}
+
+	var (
+		benchOpts  golang.BenchOpts
+		benchSpecs cli.StringSlice
+		benchOut   string
+	)
+	cBench := &cli.Command{
+		Name:      "bench",
+		Usage:     "run benchmarks (go test -bench) with every provided installed Go version and compare results like benchstat",
+		ArgsUsage: "-- [packages and go test flags...]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "versions",
+				Usage:       "installed Go versions: exact, 1.N (the newest installed patch), stable or constraint (comma-separated), the first one is baseline",
+				Required:    true,
+				Destination: &benchSpecs,
+			},
+			&cli.IntFlag{
+				Name:        "count",
+				Usage:       "number of runs of every benchmark",
+				Value:       10,
+				Destination: &benchOpts.Count,
+			},
+			&cli.StringFlag{
+				Name:        "bench",
+				Usage:       "regexp of benchmarks to run",
+				Value:       ".",
+				Destination: &benchOpts.Bench,
+			},
+			&cli.BoolFlag{
+				Name:        "benchmem",
+				Usage:       "compare memory allocations too",
+				Destination: &benchOpts.Benchmem,
+			},
+			&cli.StringFlag{
+				Name:        "out",
+				Usage:       "write raw output of every version to `DIR`/go<version>.txt (e.g. for benchstat)",
+				Destination: &benchOut,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			args := cliCtx.Args().Slice()
+			if len(args) > 0 && args[0] == "--" {
+				args = args[1:]
+			}
+			benchOpts.Versions = splitValues(benchSpecs.Value())
+			res, err := a.m.Bench(benchOpts, args)
+			if res == nil {
+				return err
+			}
+			printBench(res)
+			if benchOut != "" {
+				if err := os.MkdirAll(benchOut, 0755); err != nil {
+					return err
+				}
+				for _, r := range res.Runs {
+					output := r.Output
+					if err := writeReport(filepath.Join(benchOut, "go"+r.Version+".txt"), func(w io.Writer) error {
+						_, err := io.WriteString(w, output)
+						return err
+					}); err != nil {
+						return err
+					}
+				}
+			}
+			return err
+		},
+	}
+
+	var bisectOpts golang.BisectOpts
+	cBisect := &cli.Command{
+		Name:      "bisect",
+		Usage:     "find the first Go release command fails with (exit code 125 skips release), missing releases are installed",
+		ArgsUsage: "--good VERSION --bad VERSION -- command [args...]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "good",
+				Usage:       "Go version command succeeds with",
+				Required:    true,
+				Destination: &bisectOpts.Good,
+			},
+			&cli.StringFlag{
+				Name:        "bad",
+				Usage:       "newer Go version command fails with",
+				Required:    true,
+				Destination: &bisectOpts.Bad,
+			},
+		},
+		Action: func(cliCtx *cli.Context) error {
+			args := cliCtx.Args().Slice()
+			if len(args) > 0 && args[0] == "--" {
+				args = args[1:]
+			}
+			res, err := a.m.Bisect(bisectOpts, args)
+			if res != nil {
+				printBisect(res, err == nil)
+			}
+			return err
+		},
+	}
+
+	cExec := &cli.Command{
+		Name:      "exec",
+		Usage:     "run command with environment of Go version (GOROOT, PATH and isolated GOCACHE/GOMODCACHE/GOPATH)",
+		ArgsUsage: "[version] -- command [args...]",
+		// flags of command must be passed as is
+		SkipFlagParsing: true,
+		Action: func(cliCtx *cli.Context) error {
+			version, command := splitExecArgs(cliCtx.Args().Slice())
+			err := a.m.Exec(a.goBinPath, version, command)
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return cli.Exit("", exitErr.ExitCode())
+			}
+			return err
+		},
+	}
+
+	cEnv := &cli.Command{
+		Name:      "env",
+		Usage:     "print shell commands setting environment of Go version (current by default): eval \"$(golangver env)\"",
+		ArgsUsage: "[version]",
+		Action: func(cliCtx *cli.Context) error {
+			res, err := a.m.Env(a.goBinPath, strings.TrimPrefix(cliCtx.Args().Get(0), "v"))
+			if err != nil {
+				return err
+			}
+			printEnv(res)
+			return nil
+		},
+	}
+
+	var cleanOpts golang.CleanCacheOpts
+	cCache := &cli.Command{
+		Name:  "cache",
+		Usage: "show size of release archives cache and isolated Go environment directories",
+		Action: func(cliCtx *cli.Context) error {
+			caches, err := a.m.Caches()
+			if err != nil {
+				return err
+			}
+			printCaches(caches)
+			return nil
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:      "clean",
+				Usage:     "remove isolated Go environment directories (of provided versions only)",
+				ArgsUsage: "[version...]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:        "archives",
+						Usage:       "remove cached release archives too",
+						Destination: &cleanOpts.Archives,
+					},
+				},
+				Action: func(cliCtx *cli.Context) error {
+					cleanOpts.Versions = cliCtx.Args().Slice()
+					removed, err := a.m.CleanCaches(cleanOpts)
+					for _, c := range removed {
+						fmt.Printf("removed %s (%s)\n", c.Dir, formatSize(c.Size))
+					}
+					return err
+				},
+			},
+		},
+	}
+
+	cCompletion := &cli.Command{
+		Name:      "completion",
+		Usage:     "print shell completion script (versions are suggested by use, get and uninstall)",
+		ArgsUsage: "bash|zsh|fish",
+		Action: func(cliCtx *cli.Context) error {
+			script, err := a.completionScript(cliCtx.Args().Get(0))
+			if err != nil {
+				return err
+			}
+			fmt.Print(script)
+			return nil
+		},
+	}
+
+	cMan := &cli.Command{
+		Name:  "man",
+		Usage: "print man page (roff)",
+		Action: func(cliCtx *cli.Context) error {
+			page, err := a.manPage()
+			if err != nil {
+				return err
+			}
+			fmt.Print(page)
 			return nil
 		},
 	}
 
-	a.app.Commands = append(a.app.Commands, cInstall, cList, cUse)
+	a.app.Commands = append(a.app.Commands, cInstall, cUninstall, cImport, cList, cUse, cDoctor, cSync, cCheck, cLock, cVerify, cUpgrade,
+		cSelfUpdate, cTools, cExec, cMatrix, cBench, cBisect, cEnv, cCache, cCompletion, cMan)
+}
+
+// writeReport writes report to file.
+func writeReport(file string, write func(w io.Writer) error) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("report %s write is failed: %w", file, err)
+	}
+	return f.Close()
+}
+
+// splitValues splits comma-separated values of slice flag.
+func splitValues(values []string) []string {
+	var specs []string
+	for _, v := range values {
+		for _, spec := range strings.Split(v, ",") {
+			if spec = strings.TrimSpace(spec); spec != "" {
+				specs = append(specs, spec)
+			}
+		}
+	}
+	return specs
+}
+
+// splitExecArgs splits exec arguments to version (empty for current) and command.
+func splitExecArgs(args []string) (string, []string) {
+	for i, arg := range args {
+		if arg != "--" {
+			continue
+		}
+		var version string
+		if i > 0 {
+			version = strings.TrimPrefix(args[0], "v")
+		}
+		return version, args[i+1:]
+	}
+	if len(args) == 0 {
+		return "", nil
+	}
+	return strings.TrimPrefix(args[0], "v"), args[1:]
+}
+
+// fetch unpacks SDKs for target platform of fetchOpts without registering them as local versions.
+func (a *app) fetch(versions []string, fetchOpts golang.FetchOpts, installOpts golang.InstallOpts) error {
+	if len(versions) > 1 && fetchOpts.Dest != "" {
+		return fmt.Errorf("--dest can't be used with several versions: %s", strings.Join(versions, ", "))
+	}
+	fetchOpts.Force = installOpts.Force
+	fetchOpts.Offline = installOpts.Offline
+	fetchOpts.FromDir = installOpts.FromDir
+	for _, version := range versions {
+		res, err := a.m.Fetch(version, fetchOpts)
+		if err != nil {
+			return err
+		}
+		printFetch(res)
+	}
+	return nil
 }
 
-func mustGoBinByVersion(version string) string {
-	goBin, err := golang.BinaryPath(version)
+func (a *app) mustGoBinByVersion(version string) string {
+	goBin, err := a.m.BinaryPath(version)
 	if err != nil {
 		panic(err)
 	}
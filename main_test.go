@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/nordicdyno/golangver/golang"
+	"github.com/nordicdyno/golangver/golang/golangtest"
+)
+
+// testApp runs CLI commands with Manager of fake environment.
+type testApp struct {
+	*app
+	env    *golangtest.Env
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+}
+
+// newTestApp returns app working in fake environment with configuration file cfg (if it isn't empty).
+func newTestApp(t *testing.T, cfg string) *testApp {
+	t.Helper()
+	env, err := golangtest.NewEnv(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir := filepath.Join(env.Dir, "config")
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	if cfg != "" {
+		if err := os.MkdirAll(filepath.Join(configDir, "golangver"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(configDir, "golangver", "config.json"), []byte(cfg), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ta := &testApp{app: newApp(), env: env}
+	ta.newManager = func() (*golang.Manager, error) {
+		m := env.Manager()
+		m.Stdout = &ta.stdout
+		m.Stderr = &ta.stderr
+		return m, nil
+	}
+	return ta
+}
+
+// withReleases serves releases of versions to Manager of the app.
+func (ta *testApp) withReleases(t *testing.T, versions ...string) *golangtest.ReleaseServer {
+	t.Helper()
+	srv, err := golangtest.NewReleaseServer(versions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	newManager := ta.newManager
+	ta.newManager = func() (*golang.Manager, error) {
+		m, err := newManager()
+		if err == nil {
+			srv.Configure(m)
+		}
+		return m, err
+	}
+	return srv
+}
+
+// run runs command line (without program name) and returns exit code set by cli.Exit.
+func (ta *testApp) run(args ...string) (int, error) {
+	code := 0
+	exiter := cli.OsExiter
+	cli.OsExiter = func(c int) { code = c }
+	defer func() { cli.OsExiter = exiter }()
+
+	args = append([]string{"golangver", "--go-bin", ta.env.GoBinLink}, args...)
+	err := ta.app.run(args)
+	return code, err
+}
+
+// newSelfModuleProxy returns module proxy with the latest golangver release.
+func newSelfModuleProxy(t *testing.T, latest string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch strings.TrimPrefix(req.URL.Path, "/"+golang.SelfModule+"/") {
+		case "@latest":
+			w.Write([]byte(`{"Version":"` + latest + `"}`))
+		case "@v/list":
+			w.Write([]byte(latest + "\n"))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSelfUpdateCheckExitCode(t *testing.T) {
+	proxy := newSelfModuleProxy(t, "v1.3.0")
+	for version, want := range map[string]int{"v1.1.0": exitUpgradeAvailable, "v1.3.0": 0} {
+		ta := newTestApp(t, "")
+		ta.version = version
+		code, err := ta.run("--module-proxy", proxy.URL, "self-update", "--check")
+		if code != want {
+			t.Errorf("current %s: exit code %d (%v), want %d", version, code, err, want)
+		}
+	}
+}
+
+func TestUpdateNotice(t *testing.T) {
+	proxy := newSelfModuleProxy(t, "v1.3.0")
+	ta := newTestApp(t, `{"update_notice": true, "module_proxy": "`+proxy.URL+`"}`)
+	ta.version = "v1.1.0"
+	if _, err := ta.run("list"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(ta.stderr.String(), "NOTICE: golangver v1.3.0 is available") {
+		t.Errorf("stderr %q doesn't contain update notice", ta.stderr.String())
+	}
+}
+
+func TestUpdateNoticeDoesNotHang(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	}))
+	defer proxy.Close()
+	ta := newTestApp(t, `{"update_notice": true, "module_proxy": "`+proxy.URL+`"}`)
+	ta.version = "v1.1.0"
+
+	start := time.Now()
+	if _, err := ta.run("list"); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("list with unavailable module proxy took %s", d)
+	}
+	if strings.Contains(ta.stderr.String(), "NOTICE") {
+		t.Errorf("unexpected notice: %q", ta.stderr.String())
+	}
+}
+
+func TestGetUseList(t *testing.T) {
+	ta := newTestApp(t, "")
+	ta.withReleases(t, "1.21.3", "1.22.1")
+	if err := ta.env.WriteProjectFile("go.mod", "module example.com/p\n\ngo 1.21\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ta.run("get", "1.21", "stable"); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"1.21.3", "1.22.1"} {
+		if _, err := os.Stat(filepath.Join(ta.env.SDKPath(v), "VERSION")); err != nil {
+			t.Error(err)
+		}
+		if _, err := os.Lstat(ta.env.WrapperPath(v)); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if _, err := ta.run("use", "1.22.1"); err != nil {
+		t.Fatal(err)
+	}
+	target, err := os.Readlink(ta.env.GoBinLink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(ta.env.SDKPath("1.22.1"), "bin", "go"); target != want {
+		t.Errorf("symlink target %s, want %s", target, want)
+	}
+	// go.mod patch is declined by default answer
+	if goMod, err := ta.env.ReadProjectFile("go.mod"); err != nil || !strings.Contains(goMod, "\ngo 1.21\n") {
+		t.Errorf("go.mod %q, %v: unchanged file is expected", goMod, err)
+	}
+
+	if _, err := ta.run("use", "1.20.1"); err == nil {
+		t.Error("use of not installed version error is expected")
+	}
+	if _, err := ta.run("list"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetInvalidVersion(t *testing.T) {
+	ta := newTestApp(t, "")
+	srv := ta.withReleases(t, "1.22.1")
+	if _, err := ta.run("get", "foo"); err == nil {
+		t.Error("error is expected")
+	}
+	if len(srv.Requests()) != 0 {
+		t.Errorf("releases are requested for invalid version: %q", srv.Requests())
+	}
+}
+
+func TestMatrixFailureWritesReports(t *testing.T) {
+	ta := newTestApp(t, "")
+	for v, code := range map[string]int{"1.21.3": 0, "1.22.1": 1} {
+		if err := ta.env.AddSDK(v); err != nil {
+			t.Fatal(err)
+		}
+		check := filepath.Join(ta.env.SDKPath(v), "bin", "check")
+		if err := os.WriteFile(check, []byte("#!/bin/sh\nexit "+strconv.Itoa(code)+"\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dir := t.TempDir()
+	junit, report := filepath.Join(dir, "matrix.xml"), filepath.Join(dir, "matrix.json")
+
+	_, err := ta.run("matrix", "--versions", "1.21,1.22.1", "--junit", junit, "--json", report, "--", "check")
+	if err == nil || err.Error() != "1 of 2 runs failed" {
+		t.Fatalf("error = %v, failed run is expected", err)
+	}
+	for _, file := range []string{junit, report} {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(b, []byte("1.21.3")) || !bytes.Contains(b, []byte("1.22.1")) {
+			t.Errorf("%s doesn't contain both versions:\n%s", file, b)
+		}
+	}
+}
+
+// captureStdout returns output of render functions printing to os.Stdout while f runs.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- b
+	}()
+	f()
+	w.Close()
+	return string(<-done)
+}
+
+func TestGetSummary(t *testing.T) {
+	ta := newTestApp(t, "")
+	ta.withReleases(t, "1.21.3", "1.22.1")
+
+	var err error
+	out := captureStdout(t, func() {
+		_, err = ta.run("get", "-j", "2", "1.22", "1.20.99")
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 installs failed: 1.20.99") {
+		t.Errorf("error = %v, failed install of 1.20.99 is expected", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "VERSION") ||
+		!strings.HasPrefix(lines[1], "1.22.1 ") || !strings.Contains(lines[1], " ok ") ||
+		!strings.HasPrefix(lines[2], "1.20.99") || !strings.Contains(lines[2], "FAILED") {
+		t.Errorf("summary:\n%s", out)
+	}
+}
+
+func TestManPage(t *testing.T) {
+	page, err := newApp().manPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(page, ".nh\n.TH golangver 1\n") {
+		t.Errorf("man page header:\n%.40s", page)
+	}
+	// placeholders of usage aren't dropped
+	if !strings.Contains(page, "remove SDKs and go<version> binaries of versions") {
+		t.Error("placeholder <version> is dropped from man page")
+	}
+}
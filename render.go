@@ -0,0 +1,475 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nordicdyno/golangver/golang"
+)
+
+// Results of golang.Manager are rendered to stdout by functions below.
+
+func printList(res *golang.ListResult) {
+	printVersions := func(vl []golang.LocalVersion) {
+		for _, v := range vl {
+			mark := " "
+			if v.Current {
+				mark = "*"
+			}
+			out := fmt.Sprintf("%s %-10s  %s", mark, v.Version, v.GoBin)
+			if v.Status != "" {
+				out += "  [" + v.Status + "]"
+			}
+			if v.Policy != "" {
+				out += "  [policy: " + v.Policy + "]"
+			}
+			fmt.Println(out)
+		}
+	}
+
+	fmt.Println(" downloaded by `go install`:")
+	printVersions(res.Installed)
+	if len(res.IDEA) > 0 {
+		fmt.Print("\n downloaded by IDEA:\n")
+		printVersions(res.IDEA)
+	}
+
+	if !res.CurrentFound() {
+		fmt.Println("currentTarget:", res.CurrentTarget)
+	}
+	if res.Warning != "" {
+		fmt.Printf("\nWARNING: %s\n", res.Warning)
+	}
+
+	if res.Remote != nil {
+		fmt.Print("\n# remote Go versions:\n")
+		for _, v := range res.Remote {
+			var extraInfo string
+			if v.ReleaseNotes != "" {
+				extraInfo = "\t" + v.ReleaseNotes
+			}
+			if v.Policy != "" {
+				extraInfo += "\t[policy: " + v.Policy + "]"
+			}
+			out := fmt.Sprintf("  %-10s  %-24s%s", v.Version, v.Status, extraInfo)
+			fmt.Println(strings.TrimRight(out, " "))
+		}
+	}
+}
+
+func printInstallSummary(results []golang.InstallResult) {
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tSTATUS\tTIME\tERROR")
+	for _, r := range results {
+		status, errText := "ok", ""
+		if r.Err != nil {
+			status, errText = "FAILED", r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Version, status, r.Duration.Round(time.Second), errText)
+	}
+	tw.Flush()
+}
+
+func printFetch(res *golang.FetchResult) {
+	fmt.Printf("Go %s for %s is unpacked to %s (%s)\n", res.Version, res.Platform, res.Dest, res.Duration.Round(time.Second))
+	fmt.Printf("GOROOT=%s\n", res.Dest)
+}
+
+func printFileWrites(files []golang.FileWrite) {
+	for _, f := range files {
+		switch {
+		case f.Created:
+			fmt.Println("create", f.File)
+		case f.Backup != "":
+			fmt.Printf("overwrite %s (backup: %s)\n", f.File, f.Backup)
+		default:
+			fmt.Println("overwrite", f.File)
+		}
+	}
+}
+
+func printUse(res *golang.UseResult) {
+	for _, kv := range res.GoEnvSet {
+		fmt.Println("go env -w", kv)
+	}
+	for _, name := range res.GoEnvUnset {
+		fmt.Println("go env -u", name)
+	}
+	for _, e := range res.Editors {
+		printFileWrites(e.Files)
+		if e.Notice != "" {
+			fmt.Println("INFO:", e.Notice)
+		}
+	}
+}
+
+func printDoctor(res *golang.DoctorResult) {
+	fmt.Printf("go binary: %s -> %s\n", res.Link, res.Target)
+	fmt.Printf("GOROOT:    %s\n", res.GOROOT)
+	switch {
+	case res.SDKDiff == nil:
+		fmt.Println("SDK files: not verified (installed without manifest)")
+	case res.SDKDiff.Empty():
+		fmt.Printf("SDK files: OK (%d files match manifest)\n", len(res.Manifest.Files))
+	default:
+		fmt.Printf("SDK files: MODIFIED (%s)\n", res.SDKDiff)
+	}
+	if res.Lock != nil {
+		fmt.Printf("lock:      %s (%s)\n", res.Lock.Version, strings.Join(res.Lock.Platforms(), ", "))
+	}
+	if len(res.Editors) == 0 {
+		fmt.Println("\nno editor settings are detected")
+		return
+	}
+
+	fmt.Println("\neditors:")
+	for _, e := range res.Editors {
+		if e.Err != nil {
+			fmt.Printf("  %-12s  settings read is failed: %v\n", e.Editor, e.Err)
+			continue
+		}
+		goRoot := e.GOROOT
+		if goRoot == "" {
+			goRoot = "(not set)"
+		}
+		fmt.Printf("  %-12s  %-40s  %s\n", e.Editor, goRoot, e.Status)
+	}
+}
+
+func printSync(res *golang.SyncResult) {
+	if res.Diff == "" {
+		fmt.Printf("Go version references are up to date (found %d)\n", res.Found)
+		return
+	}
+	printFileWrites(res.Files)
+}
+
+func printCheck(res *golang.CheckResult) {
+	for _, w := range res.Warnings {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", w)
+	}
+	if len(res.Declarations) == 0 {
+		fmt.Println("Go version declarations are not found")
+	} else {
+		fmt.Println("Go version declarations:")
+		for _, d := range res.Declarations {
+			fmt.Printf("  %-36s  %-16s  %s\n", d.Location, d.Kind, d.Version)
+		}
+	}
+
+	if len(res.Problems) == 0 {
+		fmt.Println("\nOK")
+		return
+	}
+	fmt.Println("\nproblems:")
+	for _, p := range res.Problems {
+		fmt.Println("  " + p)
+	}
+}
+
+func printUpgrade(res *golang.UpgradeResult) {
+	switch {
+	case !res.Available:
+		fmt.Printf("Go %s is up to date\n", res.Current)
+	case res.Install == nil:
+		fmt.Printf("Upgrade is available: %s -> %s\n", res.Current, res.Latest)
+	case res.Use != nil:
+		printUse(res.Use)
+	}
+}
+
+func printSelfUpdate(res *golang.SelfUpdateResult) {
+	switch {
+	case res.Installed != "":
+		fmt.Printf("golangver is updated: %s -> %s\n", res.Current, res.Installed)
+	case !res.Available:
+		fmt.Printf("golangver %s is up to date\n", res.Current)
+		return
+	default:
+		fmt.Printf("Update is available: %s -> %s\n", res.Current, res.Latest)
+	}
+	if len(res.Releases) > 0 {
+		fmt.Println("Releases:", strings.Join(res.Releases, ", "))
+	}
+	fmt.Println("Changes:", res.ChangesURL)
+}
+
+func printEnv(res *golang.EnvResult) {
+	for _, v := range res.Set {
+		fmt.Printf("export %s=%s\n", v.Name, shellQuote(v.Value))
+	}
+	for _, name := range res.Unset {
+		fmt.Printf("unset %s\n", name)
+	}
+}
+
+// shellQuote quotes s for POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func printCaches(caches []golang.CacheUsage) {
+	if len(caches) == 0 {
+		fmt.Println("caches are empty")
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tCACHE\tSIZE\tDIR")
+	var total int64
+	for _, c := range caches {
+		version := c.Version
+		if version == "" {
+			version = "-"
+		}
+		total += c.Size
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", version, c.Name, formatSize(c.Size), c.Dir)
+	}
+	fmt.Fprintf(tw, "\t%s\t%s\t\n", "total", formatSize(total))
+	tw.Flush()
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func printTools(res *golang.ToolsResult) {
+	if len(res.Tools) == 0 {
+		fmt.Println("Go tools are not found")
+		return
+	}
+	fmt.Printf("current Go version: %s\n\n", res.GoVersion)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tGO\tPATH\tVERSION\t")
+	for _, t := range res.Tools {
+		mark := ""
+		if t.Outdated {
+			mark = "outdated"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", t.Name, t.GoVersion, t.Path, t.Version, mark)
+	}
+	tw.Flush()
+}
+
+func printToolRebuilds(results []golang.ToolRebuild) {
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSTATUS\tERROR")
+	for _, r := range results {
+		status, errText := "ok", ""
+		if r.Err != nil {
+			status, errText = "FAILED", r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Tool.Name, status, errText)
+	}
+	tw.Flush()
+}
+
+func printMatrix(runs []golang.MatrixRun) {
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tRESULT\tEXIT\tTIME\tERROR")
+	for _, r := range runs {
+		result, errText := "PASS", ""
+		if !r.Passed() {
+			result = "FAIL"
+		}
+		// failure of started command is in its output
+		if r.ExitCode < 0 {
+			errText = r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", r.Version, result, r.ExitCode, r.Duration.Round(time.Millisecond), errText)
+	}
+	tw.Flush()
+}
+
+func printBisect(res *golang.BisectResult, done bool) {
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tRESULT\tEXIT\tTIME\tINSTALLED")
+	for _, s := range res.Steps {
+		installed := ""
+		if s.Installed {
+			installed = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", s.Version, s.Verdict, s.ExitCode, s.Duration.Round(time.Millisecond), installed)
+	}
+	tw.Flush()
+	if !done {
+		return
+	}
+
+	fmt.Println()
+	if len(res.Skipped) > 0 {
+		fmt.Printf("first bad version is one of: %s %s (last good version is %s)\n",
+			strings.Join(res.Skipped, " "), res.FirstBad, res.LastGood)
+		return
+	}
+	fmt.Printf("first bad version is %s (last good version is %s)\n", res.FirstBad, res.LastGood)
+}
+
+// benchUnitNames are names of go test metrics used by benchstat.
+var benchUnitNames = map[string]string{
+	"ns/op":     "time/op",
+	"B/op":      "alloc/op",
+	"allocs/op": "allocs/op",
+}
+
+func printBench(res *golang.BenchResult) {
+	// table per metric unit
+	var units []string
+	byUnit := map[string][]golang.BenchComparison{}
+	for _, c := range res.Benchmarks {
+		if _, ok := byUnit[c.Unit]; !ok {
+			units = append(units, c.Unit)
+		}
+		byUnit[c.Unit] = append(byUnit[c.Unit], c)
+	}
+	if len(units) == 0 {
+		fmt.Println("\nbenchmark results are not found")
+		return
+	}
+
+	for _, unit := range units {
+		name := benchUnitNames[unit]
+		if name == "" {
+			name = unit
+		}
+		fmt.Println()
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprint(tw, "name")
+		for i, v := range res.Versions {
+			fmt.Fprintf(tw, "\tgo%s %s", v, name)
+			if i > 0 {
+				fmt.Fprint(tw, "\tdelta")
+			}
+		}
+		fmt.Fprintln(tw)
+
+		var pkg string
+		for _, c := range byUnit[unit] {
+			if c.Package != pkg {
+				pkg = c.Package
+				// empty cells keep columns aligned
+				fmt.Fprintf(tw, "pkg: %s%s\n", pkg, strings.Repeat("\t", 2*len(res.Versions)-1))
+			}
+			fmt.Fprint(tw, c.Name)
+			for i, st := range c.Stats {
+				if st == nil {
+					fmt.Fprint(tw, "\t-")
+				} else {
+					fmt.Fprintf(tw, "\t%s ± %.0f%%", formatBenchValue(st.Mean, unit), st.Diff*100)
+				}
+				if i == 0 {
+					continue
+				}
+				d := c.Deltas[i]
+				switch {
+				case d == nil:
+					fmt.Fprint(tw, "\t")
+				case d.Significant:
+					fmt.Fprintf(tw, "\t%+.2f%% (p=%.3f n=%d+%d)", d.Change*100, d.P, len(c.Stats[0].Values), len(st.Values))
+				default:
+					fmt.Fprintf(tw, "\t~ (p=%.3f n=%d+%d)", d.P, len(c.Stats[0].Values), len(st.Values))
+				}
+			}
+			fmt.Fprintln(tw)
+		}
+		tw.Flush()
+	}
+}
+
+// formatBenchValue formats metric value with 3 significant digits and scaled unit.
+func formatBenchValue(v float64, unit string) string {
+	switch unit {
+	case "ns/op":
+		for _, s := range []struct {
+			div  float64
+			unit string
+		}{{1e9, "s"}, {1e6, "ms"}, {1e3, "µs"}} {
+			if v >= s.div {
+				return formatSignificant(v/s.div) + s.unit
+			}
+		}
+		return formatSignificant(v) + "ns"
+	case "B/op":
+		for _, s := range []struct {
+			div  float64
+			unit string
+		}{{1 << 30, "GiB"}, {1 << 20, "MiB"}, {1 << 10, "KiB"}} {
+			if v >= s.div {
+				return formatSignificant(v/s.div) + s.unit
+			}
+		}
+		return formatSignificant(v) + "B"
+	}
+	return formatSignificant(v)
+}
+
+func formatSignificant(v float64) string {
+	switch {
+	case v >= 100 || v == float64(int64(v)):
+		return fmt.Sprintf("%.0f", v)
+	case v >= 10:
+		return fmt.Sprintf("%.1f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func printLock(res *golang.LockResult) {
+	action := "update"
+	if res.Created {
+		action = "create"
+	}
+	fmt.Printf("%s %s: Go %s\n", action, res.File, res.Lock.Version)
+	for _, p := range res.Lock.Platforms() {
+		a := res.Lock.Archives[p]
+		fmt.Printf("  %-14s  %s  %s\n", p, a.Filename, a.SHA256)
+	}
+}
+
+func printVerify(results []golang.VerifyResult) {
+	if len(results) == 0 {
+		fmt.Println("installed SDKs are not found")
+		return
+	}
+	fmt.Println()
+	for _, r := range results {
+		status := "OK"
+		switch {
+		case r.Err != nil:
+			status = "ERROR: " + r.Err.Error()
+		case r.Repaired:
+			status = "REPAIRED (" + r.Diff.String() + ")"
+		case !r.Diff.Empty():
+			status = "MODIFIED (" + r.Diff.String() + ")"
+		}
+		if r.ManifestRebuilt {
+			status += " [manifest is rebuilt from archive]"
+		}
+		fmt.Printf("%-10s  %s\n", r.Version, status)
+		if r.Diff == nil {
+			continue
+		}
+		for _, files := range []struct {
+			mark  string
+			names []string
+		}{{"+", r.Diff.Added}, {"M", r.Diff.Modified}, {"-", r.Diff.Missing}} {
+			for _, name := range files.names {
+				fmt.Printf("  %s %s\n", files.mark, name)
+			}
+		}
+	}
+}